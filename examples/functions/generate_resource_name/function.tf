@@ -55,3 +55,19 @@ output "azurerm_resource_group_example_3" {
     }
   }])
 }
+
+# Generate resource names for multiple instances from an integer instance (e.g., count.index), formatted by the naming pattern:
+#   - "{instance+1:%02d}" adds one to the instance and pads it with zeros to two digits
+output "azurerm_virtual_machine_examples" {
+  value = [for index in range(3) : provider::resourcenamingtool::generate_resource_name([{
+    resource_type = {
+      "fullname" = "azurerm_virtual_machine"
+    },
+    instance = {
+      "fullname" = index
+    },
+    additional_naming_patterns = {
+      "azurerm_virtual_machine" = "vm-{basename}-{environment:short}-{instance+1:%02d}"
+    }
+  }])]
+}
@@ -0,0 +1,21 @@
+# Copyright (c) Thomas Geens
+
+# Explain which naming pattern and which component values were used to generate a name
+output "azurerm_resource_group_details" {
+  value = provider::resourcenamingtool::generate_resource_name_details([{
+    resource_type = {
+      "fullname" = "azurerm_resource_group"
+    }
+    basename = {
+      "fullname" = "payroll"
+    }
+  }])
+}
+
+# Show where the value of each placeholder came from, e.g. a provider default or a value of the function call
+output "azurerm_resource_group_sources" {
+  value = {
+    for placeholder in provider::resourcenamingtool::generate_resource_name_details([]).placeholders :
+    placeholder.placeholder => placeholder.derivation == "" ? placeholder.source : "${placeholder.source} (${placeholder.derivation})"
+  }
+}
@@ -0,0 +1,31 @@
+# Copyright (c) Thomas Geens
+
+# Generate the names of a list of resource types, keyed by resource type
+output "landing_zone_names" {
+  value = provider::resourcenamingtool::generate_resource_names([{
+    basename = {
+      "fullname" = "payroll"
+    }
+  }], ["azurerm_resource_group", "azurerm_key_vault", "azurerm_storage_account"])
+}
+
+# Generate the names of a map of resource types, keyed by the keys of the map
+locals {
+  names = provider::resourcenamingtool::generate_resource_names([{
+    basename = {
+      "fullname" = "payroll"
+    }
+    environment = {
+      "fullname"  = "development"
+      "shortcode" = "dev"
+    }
+  }], {
+    frontend = "azurerm_linux_web_app"
+    backend  = "azurerm_linux_web_app"
+    database = "azurerm_mssql_database"
+  })
+}
+
+output "frontend_name" {
+  value = local.names["frontend"]
+}
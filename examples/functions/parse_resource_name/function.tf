@@ -0,0 +1,20 @@
+# Copyright (c) Thomas Geens
+
+# Decompose an existing resource group name into the components of the naming pattern of azurerm_resource_group,
+# components that match a provider default are returned with their fullname, shortcode and char
+output "azurerm_resource_group_components" {
+  value = provider::resourcenamingtool::parse_resource_name("rg-payroll-prd-we", "azurerm_resource_group")
+}
+
+# Back-fill tags of an imported resource from its name
+locals {
+  imported_name       = "rg-payroll-prd-we"
+  imported_components = provider::resourcenamingtool::parse_resource_name(local.imported_name, "azurerm_resource_group")
+}
+
+output "imported_resource_tags" {
+  value = {
+    environment = local.imported_components["environment"]["fullname"]
+    application = local.imported_components["basename"]["fullname"]
+  }
+}
@@ -0,0 +1,25 @@
+# Copyright (c) Thomas Geens
+
+# Enforce the naming convention on a name that is passed in by hand
+variable "storage_account_name" {
+  type    = string
+  default = "stexampleprdwe"
+
+  validation {
+    condition     = provider::resourcenamingtool::validate_resource_name(var.storage_account_name, "azurerm_storage_account").valid
+    error_message = join("; ", provider::resourcenamingtool::validate_resource_name(var.storage_account_name, "azurerm_storage_account").violations)
+  }
+}
+
+# Report names of existing resources that don't follow the naming convention, without failing the run
+check "legacy_resource_group_name" {
+  assert {
+    condition     = provider::resourcenamingtool::validate_resource_name("RG_Payroll_Production", "azurerm_resource_group").valid
+    error_message = join("; ", provider::resourcenamingtool::validate_resource_name("RG_Payroll_Production", "azurerm_resource_group").violations)
+  }
+}
+
+# Inspect the pattern a name was checked against and the violations that were found
+output "storage_account_name_validation" {
+  value = provider::resourcenamingtool::validate_resource_name(var.storage_account_name, "azurerm_storage_account")
+}
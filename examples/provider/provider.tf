@@ -50,190 +50,258 @@ provider "resourcenamingtool" {
     char      = "w"
   }
 
+  // Custom components, used as {department} in naming patterns unless the function call provides a department
+  additional_components = {
+    "{department}" = {
+      fullname  = "engineering"
+      shortcode = "eng"
+      char      = "e"
+    }
+  }
+
+  // Built-in naming patterns of common resource types, overridden by additional_naming_patterns
+  builtin_pattern_sets = ["azure_caf", "aws_waf", "gcp"]
+
+  // Values of the naming convention, other environments are rejected by the functions
+  allowed_values = {
+    "environment" = ["development", "dev", "test", "tst", "acceptance", "acc", "production", "prd"]
+  }
+
+  // Validation rules of the component values, "*" applies to every component
+  component_rules = {
+    "*" = {
+      char = { min_length = 1, max_length = 1 }
+    }
+    "environment" = {
+      shortcode = { pattern = "^[a-z0-9]{2,4}$" }
+    }
+    "basename" = {
+      fullname = { max_length = 12 }
+    }
+  }
+
+  // Reusable parts of naming patterns, referenced as {@name}
+  pattern_fragments = {
+    "suffix" = "{environment:short}{sep}{region:short}"
+  }
+
   additional_naming_patterns = {
     // Azure Core Resources
-    "azurerm_resource_group" : "rg-{basename}-{environment:short}-{region:short}",
-    "azurerm_virtual_network" : "vnet-{basename}-{environment:short}-{region:short}",
-    "azurerm_subnet" : "snet-{basename}-{environment:short}-{instance}",
-    "azurerm_network_security_group" : "nsg-{basename}-{environment:short}-{region:short}",
-    "azurerm_route_table" : "rt-{basename}-{environment:short}-{region:short}",
+    "azurerm_resource_group" : "rg{sep}{basename}{sep}{@suffix}",
+    "azurerm_virtual_network" : "vnet{sep}{basename}{sep}{@suffix}",
+    "azurerm_subnet" : "snet{sep}{basename}{sep}{environment:short}{sep}{instance}",
+    "azurerm_network_security_group" : "nsg{sep}{basename}{sep}{@suffix}",
+    "azurerm_route_table" : "rt{sep}{basename}{sep}{@suffix}",
 
     // Azure Compute Resources
-    "azurerm_virtual_machine" : "vm-{basename}-{environment:short}-{region:short}-{instance}",
-    "azurerm_availability_set" : "avs-{basename}-{environment:short}-{region:short}",
-    "azurerm_vm_scale_set" : "vmss-{basename}-{environment:short}-{region:short}",
-    "azurerm_kubernetes_cluster" : "aks-{basename}-{environment:short}-{region:short}",
+    "azurerm_virtual_machine" : "vm{sep}{basename}{sep}{environment:short}{sep}{region:short}{sep}{instance}",
+    "azurerm_availability_set" : "avs{sep}{basename}{sep}{@suffix}",
+    "azurerm_vm_scale_set" : "vmss{sep}{basename}{sep}{@suffix}",
+    "azurerm_kubernetes_cluster" : "aks{sep}{basename}{sep}{@suffix}",
 
     // Azure Storage Resources
-    "azurerm_storage_account" : "{basename}{environment:char}{region:char}{instance}",
-    "azurerm_storage_container" : "sc-{basename}-{environment:short}",
+    "azurerm_storage_account" : "{basename}{sep}{environment:char}{sep}{region:char}{sep}{instance}",
+    "azurerm_storage_container" : "sc{sep}{basename}{sep}{environment:short}",
 
     // Azure Database Resources
-    "azurerm_sql_server" : "sql-{basename}-{environment:short}-{region:short}",
-    "azurerm_sql_database" : "sqldb-{basename}-{environment:short}",
-    "azurerm_cosmosdb_account" : "cosmos-{basename}-{environment:short}-{region:short}",
-    "azurerm_mysql_server" : "mysql-{basename}-{environment:short}-{region:short}",
-    "azurerm_postgresql_server" : "psql-{basename}-{environment:short}-{region:short}",
+    "azurerm_sql_server" : "sql{sep}{basename}{sep}{@suffix}",
+    "azurerm_sql_database" : "sqldb{sep}{basename}{sep}{environment:short}",
+    "azurerm_cosmosdb_account" : "cosmos{sep}{basename}{sep}{@suffix}",
+    "azurerm_mysql_server" : "mysql{sep}{basename}{sep}{@suffix}",
+    "azurerm_postgresql_server" : "psql{sep}{basename}{sep}{@suffix}",
 
     // Azure App Resources
-    "azurerm_app_service" : "app-{basename}-{environment:short}-{region:short}",
-    "azurerm_app_service_plan" : "plan-{basename}-{environment:short}-{region:short}",
-    "azurerm_function_app" : "func-{basename}-{environment:short}-{region:short}",
+    "azurerm_app_service" : "app{sep}{basename}{sep}{@suffix}",
+    "azurerm_app_service_plan" : "plan{sep}{basename}{sep}{@suffix}",
+    "azurerm_function_app" : "func{sep}{basename}{sep}{@suffix}",
 
     // Azure Security Resources
-    "azurerm_key_vault" : "kv-{basename}-{environment:short}-{region:short}",
+    "azurerm_key_vault" : "kv{sep}{basename}{sep}{@suffix}",
 
     // Azure Integration Resources
-    "azurerm_servicebus_namespace" : "sb-{basename}-{environment:short}-{region:short}",
-    "azurerm_eventhub_namespace" : "evh-{basename}-{environment:short}-{region:short}",
-    "azurerm_eventgrid_topic" : "evg-{basename}-{environment:short}-{region:short}",
-    "azurerm_logic_app_workflow" : "logic-{basename}-{environment:short}",
+    "azurerm_servicebus_namespace" : "sb{sep}{basename}{sep}{@suffix}",
+    "azurerm_eventhub_namespace" : "evh{sep}{basename}{sep}{@suffix}",
+    "azurerm_eventgrid_topic" : "evg{sep}{basename}{sep}{@suffix}",
+    "azurerm_logic_app_workflow" : "logic{sep}{basename}{sep}{environment:short}",
 
     // Azure Container Resources
     "azurerm_container_registry" : "acr{basename}{environment:char}{region:char}",
-    "azurerm_container_group" : "aci-{basename}-{environment:short}",
+    "azurerm_container_group" : "aci{sep}{basename}{sep}{environment:short}",
 
     // Azure Analytics Resources
-    "azurerm_log_analytics_workspace" : "log-{basename}-{environment:short}-{region:short}",
-    "azurerm_application_insights" : "appi-{basename}-{environment:short}-{region:short}",
+    "azurerm_log_analytics_workspace" : "log{sep}{basename}{sep}{@suffix}",
+    "azurerm_application_insights" : "appi{sep}{basename}{sep}{@suffix}",
 
     // Azure Network Resources
-    "azurerm_public_ip" : "pip-{basename}-{environment:short}-{region:short}",
-    "azurerm_lb" : "lb-{basename}-{environment:short}-{region:short}",
-    "azurerm_application_gateway" : "agw-{basename}-{environment:short}-{region:short}",
-    "azurerm_network_interface" : "nic-{basename}-{environment:short}",
-    "azurerm_private_endpoint" : "pe-{basename}-{environment:short}",
+    "azurerm_public_ip" : "pip{sep}{basename}{sep}{@suffix}",
+    "azurerm_lb" : "lb{sep}{basename}{sep}{@suffix}",
+    "azurerm_application_gateway" : "agw{sep}{basename}{sep}{@suffix}",
+    "azurerm_network_interface" : "nic{sep}{basename}{sep}{environment:short}",
+    "azurerm_private_endpoint" : "pe{sep}{basename}{sep}{environment:short}",
 
     // Azure Identity Resources
-    "azurerm_user_assigned_identity" : "id-{basename}-{environment:short}-{region:short}",
+    "azurerm_user_assigned_identity" : "id{sep}{basename}{sep}{@suffix}",
 
     // Azure Monitor Resources
-    "azurerm_monitor_action_group" : "ag-{basename}-{environment:short}",
-    "azurerm_monitor_metric_alert" : "ar-{basename}-{environment:short}",
+    "azurerm_monitor_action_group" : "ag{sep}{basename}{sep}{environment:short}",
+    "azurerm_monitor_metric_alert" : "ar{sep}{basename}{sep}{environment:short}",
 
     // AWS Compute Resources
-    "aws_ec2_instance"       = "ec2-{basename}-{environment:short}-{region:short}-{instance}",
-    "aws_auto_scaling_group" = "asg-{basename}-{environment:short}-{region:short}",
-    "aws_launch_template"    = "lt-{basename}-{environment:short}-{region:short}",
+    "aws_ec2_instance"       = "ec2{sep}{basename}{sep}{environment:short}{sep}{region:short}{sep}{instance}",
+    "aws_auto_scaling_group" = "asg{sep}{basename}{sep}{@suffix}",
+    "aws_launch_template"    = "lt{sep}{basename}{sep}{@suffix}",
 
     // AWS Storage Resources
-    "aws_s3_bucket"       = "{basename}-{environment:short}-{region:short}-{instance}",
-    "aws_efs_file_system" = "efs-{basename}-{environment:short}-{region:short}",
+    "aws_s3_bucket"       = "{basename}{sep}{environment:short}{sep}{region:short}{sep}{instance}",
+    "aws_efs_file_system" = "efs{sep}{basename}{sep}{@suffix}",
 
     // AWS Database Resources
-    "aws_rds_instance"   = "rds-{basename}-{environment:short}-{region:short}",
-    "aws_rds_cluster"    = "rdsc-{basename}-{environment:short}-{region:short}",
-    "aws_dynamodb_table" = "ddb-{basename}-{environment:short}-{region:short}",
-    "aws_elasticache"    = "ec-{basename}-{environment:short}-{region:short}",
+    "aws_rds_instance"   = "rds{sep}{basename}{sep}{@suffix}",
+    "aws_rds_cluster"    = "rdsc{sep}{basename}{sep}{@suffix}",
+    "aws_dynamodb_table" = "ddb{sep}{basename}{sep}{@suffix}",
+    "aws_elasticache"    = "ec{sep}{basename}{sep}{@suffix}",
 
     // AWS Network Resources
-    "aws_vpc"            = "vpc-{basename}-{environment:short}-{region:short}",
-    "aws_subnet"         = "snet-{basename}-{environment:short}-{region:short}-{instance}",
-    "aws_security_group" = "sg-{basename}-{environment:short}-{region:short}",
-    "aws_route_table"    = "rt-{basename}-{environment:short}-{region:short}",
-    "aws_elastic_ip"     = "eip-{basename}-{environment:short}",
-    "aws_nat_gateway"    = "nat-{basename}-{environment:short}-{region:short}",
-    "aws_load_balancer"  = "lb-{basename}-{environment:short}-{region:short}",
-    "aws_target_group"   = "tg-{basename}-{environment:short}-{region:short}",
+    "aws_vpc"            = "vpc{sep}{basename}{sep}{@suffix}",
+    "aws_subnet"         = "snet{sep}{basename}{sep}{environment:short}{sep}{region:short}{sep}{instance}",
+    "aws_security_group" = "sg{sep}{basename}{sep}{@suffix}",
+    "aws_route_table"    = "rt{sep}{basename}{sep}{@suffix}",
+    "aws_elastic_ip"     = "eip{sep}{basename}{sep}{environment:short}",
+    "aws_nat_gateway"    = "nat{sep}{basename}{sep}{@suffix}",
+    "aws_load_balancer"  = "lb{sep}{basename}{sep}{@suffix}",
+    "aws_target_group"   = "tg{sep}{basename}{sep}{@suffix}",
 
     // AWS Lambda Resources
-    "aws_lambda_function" = "lambda-{basename}-{environment:short}-{region:short}",
-    "aws_layer"           = "layer-{basename}-{environment:short}-{region:short}",
+    "aws_lambda_function" = "lambda{sep}{basename}{sep}{@suffix}",
+    "aws_layer"           = "layer{sep}{basename}{sep}{@suffix}",
 
     // AWS Container Resources
-    "aws_ecr_repository" = "ecr-{basename}-{environment:short}-{region:short}",
-    "aws_ecs_cluster"    = "ecs-{basename}-{environment:short}-{region:short}",
-    "aws_eks_cluster"    = "eks-{basename}-{environment:short}-{region:short}",
+    "aws_ecr_repository" = "ecr{sep}{basename}{sep}{@suffix}",
+    "aws_ecs_cluster"    = "ecs{sep}{basename}{sep}{@suffix}",
+    "aws_eks_cluster"    = "eks{sep}{basename}{sep}{@suffix}",
 
     // AWS IAM Resources
-    "aws_iam_role"   = "role-{basename}-{environment:short}",
-    "aws_iam_policy" = "pol-{basename}-{environment:short}",
-    "aws_iam_user"   = "usr-{basename}-{environment:short}",
-    "aws_iam_group"  = "grp-{basename}-{environment:short}",
+    "aws_iam_role"   = "role{sep}{basename}{sep}{environment:short}",
+    "aws_iam_policy" = "pol{sep}{basename}{sep}{environment:short}",
+    "aws_iam_user"   = "usr{sep}{basename}{sep}{environment:short}",
+    "aws_iam_group"  = "grp{sep}{basename}{sep}{environment:short}",
 
     // AWS Monitoring Resources
-    "aws_cloudwatch_alarm" = "cwa-{basename}-{environment:short}",
-    "aws_log_group"        = "log-{basename}-{environment:short}",
-    "aws_sns_topic"        = "sns-{basename}-{environment:short}-{region:short}",
-    "aws_sqs_queue"        = "sqs-{basename}-{environment:short}-{region:short}",
+    "aws_cloudwatch_alarm" = "cwa{sep}{basename}{sep}{environment:short}",
+    "aws_log_group"        = "log{sep}{basename}{sep}{environment:short}",
+    "aws_sns_topic"        = "sns{sep}{basename}{sep}{@suffix}",
+    "aws_sqs_queue"        = "sqs{sep}{basename}{sep}{@suffix}",
 
     // AWS Application Resources
-    "aws_api_gateway"   = "api-{basename}-{environment:short}-{region:short}",
-    "aws_step_function" = "sf-{basename}-{environment:short}-{region:short}",
-    "aws_cloudfront"    = "cf-{basename}-{environment:short}",
+    "aws_api_gateway"   = "api{sep}{basename}{sep}{@suffix}",
+    "aws_step_function" = "sf{sep}{basename}{sep}{@suffix}",
+    "aws_cloudfront"    = "cf{sep}{basename}{sep}{environment:short}",
 
     // AWS Route53 Resources
-    "aws_hosted_zone" = "hz-{basename}-{environment:short}",
-    "aws_record_set"  = "rs-{basename}-{environment:short}",
+    "aws_hosted_zone" = "hz{sep}{basename}{sep}{environment:short}",
+    "aws_record_set"  = "rs{sep}{basename}{sep}{environment:short}",
 
     // GCP Compute Resources
-    "google_compute_instance"          = "vm-{basename}-{environment:short}-{region:short}-{instance}",
-    "google_compute_instance_group"    = "ig-{basename}-{environment:short}-{region:short}",
-    "google_compute_instance_template" = "it-{basename}-{environment:short}-{region:short}",
-    "google_compute_disk"              = "disk-{basename}-{environment:short}-{region:short}",
-    "google_compute_snapshot"          = "snap-{basename}-{environment:short}-{region:short}",
-    "google_compute_image"             = "img-{basename}-{environment:short}",
+    "google_compute_instance"          = "vm{sep}{basename}{sep}{environment:short}{sep}{region:short}{sep}{instance}",
+    "google_compute_instance_group"    = "ig{sep}{basename}{sep}{@suffix}",
+    "google_compute_instance_template" = "it{sep}{basename}{sep}{@suffix}",
+    "google_compute_disk"              = "disk{sep}{basename}{sep}{@suffix}",
+    "google_compute_snapshot"          = "snap{sep}{basename}{sep}{@suffix}",
+    "google_compute_image"             = "img{sep}{basename}{sep}{environment:short}",
 
     // GCP Kubernetes Resources
-    "google_container_cluster"   = "gke-{basename}-{environment:short}-{region:short}",
-    "google_container_node_pool" = "np-{basename}-{environment:short}-{region:short}",
+    "google_container_cluster"   = "gke{sep}{basename}{sep}{@suffix}",
+    "google_container_node_pool" = "np{sep}{basename}{sep}{@suffix}",
 
     // GCP Storage Resources
-    "google_storage_bucket"     = "{basename}-{environment:short}-{region:short}",
-    "google_filestore_instance" = "fs-{basename}-{environment:short}-{region:short}",
+    "google_storage_bucket"     = "{basename}{sep}{@suffix}",
+    "google_filestore_instance" = "fs{sep}{basename}{sep}{@suffix}",
 
     // GCP Network Resources
-    "google_compute_network"            = "vpc-{basename}-{environment:short}",
-    "google_compute_subnetwork"         = "subnet-{basename}-{environment:short}-{region:short}",
-    "google_compute_firewall"           = "fw-{basename}-{environment:short}",
-    "google_compute_router"             = "router-{basename}-{environment:short}-{region:short}",
-    "google_compute_address"            = "addr-{basename}-{environment:short}-{region:short}",
-    "google_compute_global_address"     = "gaddr-{basename}-{environment:short}",
-    "google_compute_forwarding_rule"    = "fr-{basename}-{environment:short}-{region:short}",
-    "google_compute_target_http_proxy"  = "http-proxy-{basename}-{environment:short}",
-    "google_compute_target_https_proxy" = "https-proxy-{basename}-{environment:short}",
-    "google_compute_ssl_certificate"    = "cert-{basename}-{environment:short}",
-    "google_compute_url_map"            = "url-map-{basename}-{environment:short}",
-    "google_compute_backend_service"    = "bes-{basename}-{environment:short}",
+    "google_compute_network"            = "vpc{sep}{basename}{sep}{environment:short}",
+    "google_compute_subnetwork"         = "subnet{sep}{basename}{sep}{@suffix}",
+    "google_compute_firewall"           = "fw{sep}{basename}{sep}{environment:short}",
+    "google_compute_router"             = "router{sep}{basename}{sep}{@suffix}",
+    "google_compute_address"            = "addr{sep}{basename}{sep}{@suffix}",
+    "google_compute_global_address"     = "gaddr{sep}{basename}{sep}{environment:short}",
+    "google_compute_forwarding_rule"    = "fr{sep}{basename}{sep}{@suffix}",
+    "google_compute_target_http_proxy"  = "http{sep}proxy{sep}{basename}{sep}{environment:short}",
+    "google_compute_target_https_proxy" = "https{sep}proxy{sep}{basename}{sep}{environment:short}",
+    "google_compute_ssl_certificate"    = "cert{sep}{basename}{sep}{environment:short}",
+    "google_compute_url_map"            = "url{sep}map{sep}{basename}{sep}{environment:short}",
+    "google_compute_backend_service"    = "bes{sep}{basename}{sep}{environment:short}",
 
     // GCP Database Resources
-    "google_sql_database_instance" = "sql-{basename}-{environment:short}-{region:short}",
-    "google_sql_database"          = "db-{basename}-{environment:short}",
-    "google_bigtable_instance"     = "bt-{basename}-{environment:short}-{region:short}",
-    "google_bigtable_table"        = "bt-tbl-{basename}-{environment:short}",
-    "google_spanner_instance"      = "spanner-{basename}-{environment:short}",
-    "google_spanner_database"      = "spanner-db-{basename}-{environment:short}",
-    "google_firestore_database"    = "fs-db-{basename}-{environment:short}",
+    "google_sql_database_instance" = "sql{sep}{basename}{sep}{@suffix}",
+    "google_sql_database"          = "db{sep}{basename}{sep}{environment:short}",
+    "google_bigtable_instance"     = "bt{sep}{basename}{sep}{@suffix}",
+    "google_bigtable_table"        = "bt{sep}tbl{sep}{basename}{sep}{environment:short}",
+    "google_spanner_instance"      = "spanner{sep}{basename}{sep}{environment:short}",
+    "google_spanner_database"      = "spanner{sep}db{sep}{basename}{sep}{environment:short}",
+    "google_firestore_database"    = "fs{sep}db{sep}{basename}{sep}{environment:short}",
 
     // GCP Serverless Resources
-    "google_cloudfunctions_function"         = "func-{basename}-{environment:short}-{region:short}",
-    "google_cloud_run_service"               = "run-{basename}-{environment:short}-{region:short}",
-    "google_app_engine_application"          = "app-{basename}-{environment:short}",
-    "google_app_engine_standard_app_version" = "app-{basename}-{environment:short}-{version}",
+    "google_cloudfunctions_function"         = "func{sep}{basename}{sep}{@suffix}",
+    "google_cloud_run_service"               = "run{sep}{basename}{sep}{@suffix}",
+    "google_app_engine_application"          = "app{sep}{basename}{sep}{environment:short}",
+    "google_app_engine_standard_app_version" = "app{sep}{basename}{sep}{environment:short}",
 
     // GCP Data Analytics Resources
-    "google_bigquery_dataset"    = "bq-ds-{basename}-{environment:short}",
-    "google_bigquery_table"      = "bq-tbl-{basename}-{environment:short}",
-    "google_dataflow_job"        = "df-{basename}-{environment:short}",
-    "google_dataproc_cluster"    = "dp-{basename}-{environment:short}-{region:short}",
-    "google_pubsub_topic"        = "ps-topic-{basename}-{environment:short}",
-    "google_pubsub_subscription" = "ps-sub-{basename}-{environment:short}",
+    "google_bigquery_dataset"    = "bq{sep}ds{sep}{basename}{sep}{environment:short}",
+    "google_bigquery_table"      = "bq{sep}tbl{sep}{basename}{sep}{environment:short}",
+    "google_dataflow_job"        = "df{sep}{basename}{sep}{environment:short}",
+    "google_dataproc_cluster"    = "dp{sep}{basename}{sep}{@suffix}",
+    "google_pubsub_topic"        = "ps{sep}topic{sep}{basename}{sep}{environment:short}",
+    "google_pubsub_subscription" = "ps{sep}sub{sep}{basename}{sep}{environment:short}",
 
     // GCP IAM Resources
-    "google_service_account"         = "sa-{basename}-{environment:short}",
-    "google_project_iam_custom_role" = "role-{basename}-{environment:short}",
+    "google_service_account"         = "sa{sep}{basename}{sep}{environment:short}",
+    "google_project_iam_custom_role" = "role{sep}{basename}{sep}{environment:short}",
 
     // GCP Security Resources
-    "google_kms_key_ring"          = "kr-{basename}-{environment:short}-{region:short}",
-    "google_kms_crypto_key"        = "kms-{basename}-{environment:short}",
-    "google_secret_manager_secret" = "secret-{basename}-{environment:short}",
+    "google_kms_key_ring"          = "kr{sep}{basename}{sep}{@suffix}",
+    "google_kms_crypto_key"        = "kms{sep}{basename}{sep}{environment:short}",
+    "google_secret_manager_secret" = "secret{sep}{basename}{sep}{environment:short}",
 
     // GCP Monitoring Resources
-    "google_monitoring_alert_policy"         = "alert-{basename}-{environment:short}",
-    "google_logging_metric"                  = "log-{basename}-{environment:short}",
-    "google_monitoring_notification_channel" = "notif-{basename}-{environment:short}",
-    "google_monitoring_dashboard"            = "dash-{basename}-{environment:short}"
+    "google_monitoring_alert_policy"         = "alert{sep}{basename}{sep}{environment:short}",
+    "google_logging_metric"                  = "log{sep}{basename}{sep}{environment:short}",
+    "google_monitoring_notification_channel" = "notif{sep}{basename}{sep}{environment:short}",
+    "google_monitoring_dashboard"            = "dash{sep}{basename}{sep}{environment:short}"
+
+  }
 
+  // Separator used for {sep} placeholders, can be overridden per resource type in naming_rules and per function call
+  default_separator = "-"
+
+  // Seed mixed into the digest produced by {hash:N} placeholders
+  hash_seed = "contoso"
+
+  // Shorten names that exceed the max_length of their resource type instead of rejecting them
+  shortening = {
+    steps           = ["abbreviate", "remove_separators", "truncate"]
+    component_order = ["instance", "region", "environment", "basename"]
+    hash_length     = 4
+  }
+
+  // Override or extend the built-in naming rules for specific resource types
+  naming_rules = {
+    "azurerm_storage_account" = {
+      max_length = 20
+      separator  = ""
+    },
+    "azurerm_sql_database" = {
+      separator = "_"
+    },
+    "my_custom_resource" = {
+      min_length          = 5
+      max_length          = 32
+      allowed_characters  = "a-z0-9-"
+      case                = "lower"
+      leading_characters  = "a-z"
+      trailing_characters = "a-z0-9"
+      sanitize            = ["lower", "replace:_:-"]
+    }
   }
 }
 
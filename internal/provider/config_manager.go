@@ -22,202 +22,9 @@ var (
 	fileLockTimeout    = 10 * time.Second
 	lockRetryInterval  = 50 * time.Millisecond
 	globalConfigMutex  = &sync.Mutex{} // Memory-level lock for in-process synchronization
-	// Define builtin default naming patterns following cloud provider best practices:
-	// - Azure: Following Microsoft's Cloud Adoption Framework (CAF) naming conventions
-	// - AWS: Following AWS Well-Architected Framework (WAF) and AWS service-specific naming guidelines
-	// - GCP: Following Google Cloud's recommended naming conventions
-	builtin_NamingPatterns = map[string]string{
-		// Azure RM Resources - Based on CAF naming conventions
-		// https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-naming
-		// // Azure Core Resources
-		// "azurerm_resource_group":         "rg-{basename}-{environment:short}-{region:short}",
-		// "azurerm_virtual_network":        "vnet-{basename}-{environment:short}-{region:short}",
-		// "azurerm_subnet":                 "snet-{basename}-{environment:short}-{instance}",
-		// "azurerm_network_security_group": "nsg-{basename}-{environment:short}-{region:short}",
-		// "azurerm_route_table":            "rt-{basename}-{environment:short}-{region:short}",
-
-		// // Azure Compute Resources
-		// "azurerm_virtual_machine":    "vm-{basename}-{environment:short}-{region:short}-{instance}",
-		// "azurerm_availability_set":   "avs-{basename}-{environment:short}-{region:short}",
-		// "azurerm_vm_scale_set":       "vmss-{basename}-{environment:short}-{region:short}",
-		// "azurerm_kubernetes_cluster": "aks-{basename}-{environment:short}-{region:short}",
-
-		// // Azure Storage Resources
-		// "azurerm_storage_account":   "{basename}{environment:char}{region:char}{instance}",
-		// "azurerm_storage_container": "sc-{basename}-{environment:short}",
-
-		// // Azure Database Resources
-		// "azurerm_sql_server":        "sql-{basename}-{environment:short}-{region:short}",
-		// "azurerm_sql_database":      "sqldb-{basename}-{environment:short}",
-		// "azurerm_cosmosdb_account":  "cosmos-{basename}-{environment:short}-{region:short}",
-		// "azurerm_mysql_server":      "mysql-{basename}-{environment:short}-{region:short}",
-		// "azurerm_postgresql_server": "psql-{basename}-{environment:short}-{region:short}",
-
-		// // Azure App Resources
-		// "azurerm_app_service":      "app-{basename}-{environment:short}-{region:short}",
-		// "azurerm_app_service_plan": "plan-{basename}-{environment:short}-{region:short}",
-		// "azurerm_function_app":     "func-{basename}-{environment:short}-{region:short}",
-
-		// // Azure Security Resources
-		// "azurerm_key_vault": "kv-{basename}-{environment:short}-{region:short}",
-
-		// // Azure Integration Resources
-		// "azurerm_servicebus_namespace": "sb-{basename}-{environment:short}-{region:short}",
-		// "azurerm_eventhub_namespace":   "evh-{basename}-{environment:short}-{region:short}",
-		// "azurerm_eventgrid_topic":      "evg-{basename}-{environment:short}-{region:short}",
-		// "azurerm_logic_app_workflow":   "logic-{basename}-{environment:short}",
-
-		// // Azure Container Resources
-		// "azurerm_container_registry": "acr{basename}{environment:char}{region:char}",
-		// "azurerm_container_group":    "aci-{basename}-{environment:short}",
-
-		// // Azure Analytics Resources
-		// "azurerm_log_analytics_workspace": "log-{basename}-{environment:short}-{region:short}",
-		// "azurerm_application_insights":    "appi-{basename}-{environment:short}-{region:short}",
-
-		// // Azure Network Resources
-		// "azurerm_public_ip":           "pip-{basename}-{environment:short}-{region:short}",
-		// "azurerm_lb":                  "lb-{basename}-{environment:short}-{region:short}",
-		// "azurerm_application_gateway": "agw-{basename}-{environment:short}-{region:short}",
-		// "azurerm_network_interface":   "nic-{basename}-{environment:short}",
-		// "azurerm_private_endpoint":    "pe-{basename}-{environment:short}",
-
-		// // Azure Identity Resources
-		// "azurerm_user_assigned_identity": "id-{basename}-{environment:short}-{region:short}",
-
-		// // Azure Monitor Resources
-		// "azurerm_monitor_action_group": "ag-{basename}-{environment:short}",
-		// "azurerm_monitor_metric_alert": "ar-{basename}-{environment:short}",
-
-		// // AWS Resources - Following AWS Well-Architected Framework and service-specific naming guidelines
-		// // https://docs.aws.amazon.com/general/latest/gr/aws-arns-and-namespaces.html
-		// // https://docs.aws.amazon.com/whitepapers/latest/tagging-best-practices/tagging-best-practices.html
-		// // AWS Compute Resources
-		// "aws_ec2_instance":       "ec2-{basename}-{environment:short}-{region:short}-{instance}",
-		// "aws_auto_scaling_group": "asg-{basename}-{environment:short}-{region:short}",
-		// "aws_launch_template":    "lt-{basename}-{environment:short}-{region:short}",
-
-		// // AWS Storage Resources
-		// "aws_s3_bucket":       "{basename}-{environment:short}-{region:short}-{instance}",
-		// "aws_efs_file_system": "efs-{basename}-{environment:short}-{region:short}",
-
-		// // AWS Database Resources
-		// "aws_rds_instance":   "rds-{basename}-{environment:short}-{region:short}",
-		// "aws_rds_cluster":    "rdsc-{basename}-{environment:short}-{region:short}",
-		// "aws_dynamodb_table": "ddb-{basename}-{environment:short}-{region:short}",
-		// "aws_elasticache":    "ec-{basename}-{environment:short}-{region:short}",
-
-		// // AWS Network Resources
-		// "aws_vpc":            "vpc-{basename}-{environment:short}-{region:short}",
-		// "aws_subnet":         "snet-{basename}-{environment:short}-{region:short}-{instance}",
-		// "aws_security_group": "sg-{basename}-{environment:short}-{region:short}",
-		// "aws_route_table":    "rt-{basename}-{environment:short}-{region:short}",
-		// "aws_elastic_ip":     "eip-{basename}-{environment:short}",
-		// "aws_nat_gateway":    "nat-{basename}-{environment:short}-{region:short}",
-		// "aws_load_balancer":  "lb-{basename}-{environment:short}-{region:short}",
-		// "aws_target_group":   "tg-{basename}-{environment:short}-{region:short}",
-
-		// // AWS Lambda Resources
-		// "aws_lambda_function": "lambda-{basename}-{environment:short}-{region:short}",
-		// "aws_layer":           "layer-{basename}-{environment:short}-{region:short}",
-
-		// // AWS Container Resources
-		// "aws_ecr_repository": "ecr-{basename}-{environment:short}-{region:short}",
-		// "aws_ecs_cluster":    "ecs-{basename}-{environment:short}-{region:short}",
-		// "aws_eks_cluster":    "eks-{basename}-{environment:short}-{region:short}",
-
-		// // AWS IAM Resources
-		// "aws_iam_role":   "role-{basename}-{environment:short}",
-		// "aws_iam_policy": "pol-{basename}-{environment:short}",
-		// "aws_iam_user":   "usr-{basename}-{environment:short}",
-		// "aws_iam_group":  "grp-{basename}-{environment:short}",
-
-		// // AWS Monitoring Resources
-		// "aws_cloudwatch_alarm": "cwa-{basename}-{environment:short}",
-		// "aws_log_group":        "log-{basename}-{environment:short}",
-		// "aws_sns_topic":        "sns-{basename}-{environment:short}-{region:short}",
-		// "aws_sqs_queue":        "sqs-{basename}-{environment:short}-{region:short}",
-
-		// // AWS Application Resources
-		// "aws_api_gateway":   "api-{basename}-{environment:short}-{region:short}",
-		// "aws_step_function": "sf-{basename}-{environment:short}-{region:short}",
-		// "aws_cloudfront":    "cf-{basename}-{environment:short}",
-
-		// // AWS Route53 Resources
-		// "aws_hosted_zone": "hz-{basename}-{environment:short}",
-		// "aws_record_set":  "rs-{basename}-{environment:short}",
-
-		// // Google Cloud Platform Resources - Following Google Cloud's naming conventions
-		// // https://cloud.google.com/resource-manager/docs/creating-managing-projects#identifying_projects
-		// // https://cloud.google.com/architecture/best-practices-naming-resources
-		// // GCP Compute Resources
-		// "google_compute_instance":          "vm-{basename}-{environment:short}-{region:short}-{instance}",
-		// "google_compute_instance_group":    "ig-{basename}-{environment:short}-{region:short}",
-		// "google_compute_instance_template": "it-{basename}-{environment:short}-{region:short}",
-		// "google_compute_disk":              "disk-{basename}-{environment:short}-{region:short}",
-		// "google_compute_snapshot":          "snap-{basename}-{environment:short}-{region:short}",
-		// "google_compute_image":             "img-{basename}-{environment:short}",
-
-		// // GCP Kubernetes Resources
-		// "google_container_cluster":   "gke-{basename}-{environment:short}-{region:short}",
-		// "google_container_node_pool": "np-{basename}-{environment:short}-{region:short}",
-
-		// // GCP Storage Resources
-		// "google_storage_bucket":     "{basename}-{environment:short}-{region:short}",
-		// "google_filestore_instance": "fs-{basename}-{environment:short}-{region:short}",
-
-		// // GCP Network Resources
-		// "google_compute_network":            "vpc-{basename}-{environment:short}",
-		// "google_compute_subnetwork":         "subnet-{basename}-{environment:short}-{region:short}",
-		// "google_compute_firewall":           "fw-{basename}-{environment:short}",
-		// "google_compute_router":             "router-{basename}-{environment:short}-{region:short}",
-		// "google_compute_address":            "addr-{basename}-{environment:short}-{region:short}",
-		// "google_compute_global_address":     "gaddr-{basename}-{environment:short}",
-		// "google_compute_forwarding_rule":    "fr-{basename}-{environment:short}-{region:short}",
-		// "google_compute_target_http_proxy":  "http-proxy-{basename}-{environment:short}",
-		// "google_compute_target_https_proxy": "https-proxy-{basename}-{environment:short}",
-		// "google_compute_ssl_certificate":    "cert-{basename}-{environment:short}",
-		// "google_compute_url_map":            "url-map-{basename}-{environment:short}",
-		// "google_compute_backend_service":    "bes-{basename}-{environment:short}",
-
-		// // GCP Database Resources
-		// "google_sql_database_instance": "sql-{basename}-{environment:short}-{region:short}",
-		// "google_sql_database":          "db-{basename}-{environment:short}",
-		// "google_bigtable_instance":     "bt-{basename}-{environment:short}-{region:short}",
-		// "google_bigtable_table":        "bt-tbl-{basename}-{environment:short}",
-		// "google_spanner_instance":      "spanner-{basename}-{environment:short}",
-		// "google_spanner_database":      "spanner-db-{basename}-{environment:short}",
-		// "google_firestore_database":    "fs-db-{basename}-{environment:short}",
-
-		// // GCP Serverless Resources
-		// "google_cloudfunctions_function":         "func-{basename}-{environment:short}-{region:short}",
-		// "google_cloud_run_service":               "run-{basename}-{environment:short}-{region:short}",
-		// "google_app_engine_application":          "app-{basename}-{environment:short}",
-		// "google_app_engine_standard_app_version": "app-{basename}-{environment:short}-{version}",
-
-		// // GCP Data Analytics Resources
-		// "google_bigquery_dataset":    "bq-ds-{basename}-{environment:short}",
-		// "google_bigquery_table":      "bq-tbl-{basename}-{environment:short}",
-		// "google_dataflow_job":        "df-{basename}-{environment:short}",
-		// "google_dataproc_cluster":    "dp-{basename}-{environment:short}-{region:short}",
-		// "google_pubsub_topic":        "ps-topic-{basename}-{environment:short}",
-		// "google_pubsub_subscription": "ps-sub-{basename}-{environment:short}",
-
-		// // GCP IAM Resources
-		// "google_service_account":         "sa-{basename}-{environment:short}",
-		// "google_project_iam_custom_role": "role-{basename}-{environment:short}",
-
-		// // GCP Security Resources
-		// "google_kms_key_ring":          "kr-{basename}-{environment:short}-{region:short}",
-		// "google_kms_crypto_key":        "kms-{basename}-{environment:short}",
-		// "google_secret_manager_secret": "secret-{basename}-{environment:short}",
-
-		// // GCP Monitoring Resources
-		// "google_monitoring_alert_policy":         "alert-{basename}-{environment:short}",
-		// "google_logging_metric":                  "log-{basename}-{environment:short}",
-		// "google_monitoring_notification_channel": "notif-{basename}-{environment:short}",
-		// "google_monitoring_dashboard":            "dash-{basename}-{environment:short}",
-	}
+	// configBaseDir returns the directory the configuration file is saved under, the tests replace it with a
+	// temporary directory so they don't write into the source tree
+	configBaseDir = os.Getwd
 )
 
 // processComponentFromMap extracts component values from a map and creates a ComponentValueObject
@@ -234,7 +41,7 @@ func processComponentFromMap(ctx context.Context, componentMap map[string]interf
 // This ensures consistent path resolution across all functions
 func getConfigPath(ctx context.Context) string {
 	logDebug(ctx, "Invoking getConfigPath")
-	workDir, err := os.Getwd()
+	workDir, err := configBaseDir()
 	configDir := ""
 	if err != nil {
 		logDebug(ctx, "getConfigPath: Failed to get working directory path, default to temp directory path: %s", err)
@@ -480,6 +287,21 @@ func loadProviderConfigFromFile(ctx context.Context) *resourcenamingtoolProvider
 		return nil
 	}
 
+	// Handle the default values of the built-in components
+	defaults := make(map[string]ComponentValueObject)
+	for _, definition := range componentRegistry {
+		if component, ok := rawConfig[definition.ConfigKey()].(map[string]interface{}); ok {
+			if componentValue, ok := processComponentFromMap(ctx, component); ok {
+				defaults[definition.Name] = componentValue
+			}
+		}
+	}
+	if componentDefaults, diags := componentDefaultsToMap(defaults); diags.HasError() {
+		logError(ctx, "Failed to create ComponentDefaults map: %s", diags.Errors()[0].Detail())
+	} else {
+		config.ComponentDefaults = componentDefaults
+	}
+
 	// Handle AdditionalComponents
 	if components, ok := rawConfig["AdditionalComponents"].(map[string]interface{}); ok && len(components) > 0 {
 		elements := make(map[string]attr.Value)
@@ -498,6 +320,22 @@ func loadProviderConfigFromFile(ctx context.Context) *resourcenamingtoolProvider
 		}
 	}
 
+	// Handle the BuiltinPatternSets list
+	if sets, ok := rawConfig["BuiltinPatternSets"].([]interface{}); ok {
+		setElements := make([]attr.Value, 0, len(sets))
+		for _, v := range sets {
+			if strVal, ok := v.(string); ok {
+				setElements = append(setElements, types.StringValue(strVal))
+			}
+		}
+		setsList, diags := types.ListValue(types.StringType, setElements)
+		if diags.HasError() {
+			logError(ctx, "Failed to create BuiltinPatternSets list: %s", diags)
+		} else {
+			config.BuiltinPatternSets = setsList
+		}
+	}
+
 	// Handle the AdditionalNamingPatterns map
 	// Always initialize the map even if empty from the JSON
 	elements := make(map[string]attr.Value)
@@ -524,6 +362,120 @@ func loadProviderConfigFromFile(ctx context.Context) *resourcenamingtoolProvider
 		logError(ctx, "Failed to create AdditionalNamingPatterns map: %s", diags)
 	}
 
+	// Handle the PatternFragments map
+	if fragments, ok := rawConfig["PatternFragments"].(map[string]interface{}); ok {
+		logDebug(ctx, "Found PatternFragments in config JSON with %d entries", len(fragments))
+		fragmentElements := make(map[string]attr.Value)
+		for k, v := range fragments {
+			if strVal, ok := v.(string); ok {
+				fragmentElements[k] = types.StringValue(strVal)
+			}
+		}
+		fragmentsMap, diags := types.MapValue(types.StringType, fragmentElements)
+		if diags.HasError() {
+			logError(ctx, "Failed to create PatternFragments map: %s", diags)
+		} else {
+			config.PatternFragments = fragmentsMap
+		}
+	}
+
+	// Handle the ResourceTypeAbbreviations map
+	if abbreviations, ok := rawConfig["ResourceTypeAbbreviations"].(map[string]interface{}); ok {
+		logDebug(ctx, "Found ResourceTypeAbbreviations in config JSON with %d entries", len(abbreviations))
+		abbreviationsMap, err := abbreviationsFromJSON(ctx, abbreviations)
+		if err != nil {
+			logError(ctx, "Failed to create ResourceTypeAbbreviations map: %s", err.Error())
+		} else {
+			config.ResourceTypeAbbreviations = abbreviationsMap
+		}
+	}
+
+	// Handle the RegionAbbreviations map
+	if abbreviations, ok := rawConfig["RegionAbbreviations"].(map[string]interface{}); ok {
+		logDebug(ctx, "Found RegionAbbreviations in config JSON with %d entries", len(abbreviations))
+		abbreviationsMap, err := abbreviationsFromJSON(ctx, abbreviations)
+		if err != nil {
+			logError(ctx, "Failed to create RegionAbbreviations map: %s", err.Error())
+		} else {
+			config.RegionAbbreviations = abbreviationsMap
+		}
+	}
+
+	// Handle the NamingRules map
+	if rules, ok := rawConfig["NamingRules"].(map[string]interface{}); ok {
+		logDebug(ctx, "Found NamingRules in config JSON with %d entries", len(rules))
+		rulesMap, err := namingRulesFromJSON(ctx, rules)
+		if err != nil {
+			logError(ctx, "Failed to create NamingRules map: %s", err.Error())
+		} else {
+			config.NamingRules = rulesMap
+		}
+	}
+
+	// Handle the Shortening object
+	if strategy, ok := rawConfig["Shortening"].(map[string]interface{}); ok {
+		logDebug(ctx, "Found Shortening in config JSON")
+		shortening, err := shorteningFromJSON(ctx, strategy)
+		if err != nil {
+			logError(ctx, "Failed to create Shortening object: %s", err.Error())
+		} else {
+			config.Shortening = shortening
+		}
+	}
+
+	// Handle the AllowedValues map
+	if allowed, ok := rawConfig["AllowedValues"].(map[string]interface{}); ok {
+		logDebug(ctx, "Found AllowedValues in config JSON with %d entries", len(allowed))
+		allowedElements := make(map[string][]string)
+		for name, values := range allowed {
+			if list, ok := values.([]interface{}); ok {
+				allowedElements[name] = make([]string, 0, len(list))
+				for _, value := range list {
+					if strVal, ok := value.(string); ok {
+						allowedElements[name] = append(allowedElements[name], strVal)
+					}
+				}
+			}
+		}
+		allowedMap, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, allowedElements)
+		if diags.HasError() {
+			logError(ctx, "Failed to create AllowedValues map: %s", diags)
+		} else {
+			config.AllowedValues = allowedMap
+		}
+	}
+
+	// Handle the ComponentRules map
+	if rules, ok := rawConfig["ComponentRules"].(map[string]interface{}); ok {
+		logDebug(ctx, "Found ComponentRules in config JSON with %d entries", len(rules))
+		rulesMap, err := componentRulesFromJSON(ctx, rules)
+		if err != nil {
+			logError(ctx, "Failed to create ComponentRules map: %s", err.Error())
+		} else {
+			config.ComponentRules = rulesMap
+		}
+	}
+
+	// Handle the HashSeed string
+	if seed, ok := rawConfig["HashSeed"].(string); ok {
+		config.HashSeed = types.StringValue(seed)
+	}
+
+	// Handle the DefaultSeparator string
+	if separator, ok := rawConfig["DefaultSeparator"].(string); ok {
+		config.DefaultSeparator = types.StringValue(separator)
+	}
+
+	// Handle the Transliterate bool
+	if transliterate, ok := rawConfig["Transliterate"].(bool); ok {
+		config.Transliterate = types.BoolValue(transliterate)
+	}
+
+	// Handle the LocationFallbackToRegion bool
+	if fallback, ok := rawConfig["LocationFallbackToRegion"].(bool); ok {
+		config.LocationFallbackToRegion = types.BoolValue(fallback)
+	}
+
 	// Log configuration loading complete
 	logDebug(ctx, "Successfully loaded provider config")
 	return config
@@ -0,0 +1,314 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// abbreviationCatalogFiles holds the built-in abbreviation catalogs, one directory per abbreviated component with one
+// JSON file per catalog named after the catalog
+//
+//go:embed catalogs/resource_types/*.json catalogs/regions/*.json
+var abbreviationCatalogFiles embed.FS
+
+// abbreviation is the shortcode and char of the fullname of a component, the char defaults to the first character of
+// the shortcode
+type abbreviation struct {
+	Shortcode string `json:"shortcode"`
+	Char      string `json:"char,omitempty"`
+}
+
+// catalogAbbreviation is an entry of an abbreviation catalog, the aliases are other fullnames of the same value such
+// as the display name of a region
+type catalogAbbreviation struct {
+	abbreviation
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// abbreviationCatalog is a versioned catalog of abbreviations keyed by the fullname of the component
+type abbreviationCatalog struct {
+	catalogInfo
+	Abbreviations map[string]catalogAbbreviation `json:"abbreviations"`
+
+	// index holds the abbreviations keyed by the abbreviationKey of the fullnames and aliases
+	index map[string]abbreviation
+}
+
+// abbreviatedComponent describes a component whose shortcode and char are looked up by fullname when the function
+// call or the provider default only provides the fullname
+type abbreviatedComponent struct {
+	// CatalogDir is the directory of the built-in abbreviation catalogs of the component
+	CatalogDir string
+	// ProviderAttribute is the provider attribute that overrides and extends the built-in catalogs
+	ProviderAttribute string
+	// Configured returns the value of ProviderAttribute
+	Configured func(*resourcenamingtoolProviderModel) types.Map
+}
+
+// abbreviatedComponents holds the components with abbreviation catalogs keyed by component name
+var abbreviatedComponents = map[string]abbreviatedComponent{
+	"resource_type": {
+		CatalogDir:        "catalogs/resource_types",
+		ProviderAttribute: "resource_type_abbreviations",
+		Configured:        func(m *resourcenamingtoolProviderModel) types.Map { return m.ResourceTypeAbbreviations },
+	},
+	"region": {
+		CatalogDir:        "catalogs/regions",
+		ProviderAttribute: "region_abbreviations",
+		Configured:        func(m *resourcenamingtoolProviderModel) types.Map { return m.RegionAbbreviations },
+	},
+	"location": {
+		CatalogDir:        "catalogs/regions",
+		ProviderAttribute: "region_abbreviations",
+		Configured:        func(m *resourcenamingtoolProviderModel) types.Map { return m.RegionAbbreviations },
+	},
+}
+
+// abbreviationKey returns the key fullnames are looked up by: lower case without whitespace, so the display name
+// "Germany West Central" finds the Azure region germanywestcentral
+func abbreviationKey(fullname string) string {
+	return strings.ToLower(strings.Join(strings.Fields(fullname), ""))
+}
+
+// builtinAbbreviationCatalogs holds the built-in abbreviation catalogs of every abbreviated component, in alphabetical
+// order of the catalog names
+var builtinAbbreviationCatalogs = mustLoadAbbreviationCatalogs(abbreviationCatalogFiles, abbreviatedComponents)
+
+// loadAbbreviationCatalogs reads the abbreviation catalogs in dir, every abbreviation must have a shortcode and every
+// fullname and alias must be unique within its catalog
+func loadAbbreviationCatalogs(files fs.FS, dir string) ([]abbreviationCatalog, error) {
+	var catalogs []abbreviationCatalog
+	err := readCatalogs(files, dir, "abbreviation catalog", func(data []byte) (catalogInfo, error) {
+		var catalog abbreviationCatalog
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return catalogInfo{}, err
+		}
+		catalog.index = make(map[string]abbreviation)
+		for fullname, abbr := range catalog.Abbreviations {
+			if abbr.Shortcode == "" {
+				return catalogInfo{}, fmt.Errorf("the abbreviation of %q has no shortcode", fullname)
+			}
+			for _, name := range append([]string{fullname}, abbr.Aliases...) {
+				if _, ok := catalog.index[abbreviationKey(name)]; ok {
+					return catalogInfo{}, fmt.Errorf("%q is abbreviated more than once", name)
+				}
+				catalog.index[abbreviationKey(name)] = abbr.abbreviation
+			}
+		}
+		catalogs = append(catalogs, catalog)
+		return catalog.catalogInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return catalogs, nil
+}
+
+// mustLoadAbbreviationCatalogs loads the embedded abbreviation catalogs of the components, which are validated by the
+// tests
+func mustLoadAbbreviationCatalogs(files fs.FS, components map[string]abbreviatedComponent) map[string][]abbreviationCatalog {
+	catalogs := make(map[string][]abbreviationCatalog, len(components))
+	for name, component := range components {
+		componentCatalogs, err := loadAbbreviationCatalogs(files, component.CatalogDir)
+		if err != nil {
+			panic(err)
+		}
+		catalogs[name] = componentCatalogs
+	}
+	return catalogs
+}
+
+// decodeAbbreviations decodes the abbreviation attributes of the configuration once, so they aren't decoded again
+// for every abbreviated placeholder. Functions call it before they look up abbreviations, malformed abbreviations
+// are returned as errors.
+func decodeAbbreviations(ctx context.Context, config *resourcenamingtoolProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	components := make([]string, 0, len(abbreviatedComponents))
+	for componentName := range abbreviatedComponents {
+		components = append(components, componentName)
+	}
+	sort.Strings(components)
+
+	decoded := make(map[string]map[string]abbreviation)
+	for _, componentName := range components {
+		component := abbreviatedComponents[componentName]
+		if _, ok := decoded[component.ProviderAttribute]; ok {
+			continue
+		}
+		abbreviations, abbreviationDiags := abbreviationsFromMap(ctx, component.Configured(config))
+		if abbreviationDiags.HasError() {
+			diags.AddError("Invalid Abbreviations", fmt.Sprintf("The %s provider attribute cannot be read: %s",
+				component.ProviderAttribute, abbreviationDiags.Errors()[0].Detail()))
+		}
+		decoded[component.ProviderAttribute] = abbreviations
+	}
+	config.abbreviations = decoded
+	return diags
+}
+
+// lookupAbbreviation returns the abbreviation of the fullname of a component and where it came from: the provider
+// attribute of the component, which takes precedence, or a built-in catalog. Fullnames are compared by abbreviationKey.
+// The provider attribute is only consulted when the configuration was passed to decodeAbbreviations.
+func lookupAbbreviation(config resourcenamingtoolProviderModel, componentName, fullname string) (abbreviation, string, bool) {
+	component, ok := abbreviatedComponents[componentName]
+	if !ok || fullname == "" {
+		return abbreviation{}, "", false
+	}
+
+	key := abbreviationKey(fullname)
+	for name, abbr := range config.abbreviations[component.ProviderAttribute] {
+		if abbreviationKey(name) == key && abbr.Shortcode != "" {
+			return abbr.withDefaultChar(), component.ProviderAttribute, true
+		}
+	}
+	for _, catalog := range builtinAbbreviationCatalogs[componentName] {
+		if abbr, ok := catalog.index[key]; ok {
+			return abbr.withDefaultChar(), fmt.Sprintf("the %s catalog", catalog), true
+		}
+	}
+	return abbreviation{}, "", false
+}
+
+// withDefaultChar returns the abbreviation with the first character of the shortcode as char when it has no char
+func (a abbreviation) withDefaultChar() abbreviation {
+	if a.Char == "" {
+		a.Char = runePrefix(a.Shortcode, 1)
+	}
+	return a
+}
+
+// validate returns a description of every problem of the abbreviation of fullname in the attrName attribute, the
+// shortcode and char are inserted into names as literals like the component values
+func (a abbreviation) validate(fullname, attrName string) []string {
+	var problems []string
+	if a.Shortcode == "" {
+		problems = append(problems, fmt.Sprintf("The shortcode of %q in %s must not be empty", fullname, attrName))
+	}
+	if utf8.RuneCountInString(a.Char) > 1 {
+		problems = append(problems, fmt.Sprintf("The char %q of %q in %s must be a single character", a.Char, fullname, attrName))
+	}
+	for _, attribute := range []struct{ name, value string }{{"shortcode", a.Shortcode}, {"char", a.Char}} {
+		if metacharacter := findPatternMetacharacter(attribute.value); metacharacter != "" {
+			problems = append(problems, fmt.Sprintf("The %s %q of %q in %s contains %q, which is reserved for naming patterns", attribute.name, attribute.value, fullname, attrName, metacharacter))
+		}
+	}
+	return problems
+}
+
+// abbreviateFullname returns the shortcode or char, depending on the format, of the fullname of a component from the
+// abbreviation catalogs and the derivation of the value, or empty strings when the fullname has no abbreviation
+func abbreviateFullname(ctx context.Context, config resourcenamingtoolProviderModel, componentName, format, fullname string) (string, string) {
+	abbr, origin, ok := lookupAbbreviation(config, componentName, fullname)
+	if !ok {
+		return "", ""
+	}
+	logDebugWithFields(ctx, "Using abbreviation of fullname", map[string]interface{}{
+		"component": componentName,
+		"fullname":  fullname,
+		"origin":    origin,
+	})
+	if format == "char" {
+		return abbr.Char, "char not set, using the abbreviation from " + origin
+	}
+	return abbr.Shortcode, "shortcode not set, using the abbreviation from " + origin
+}
+
+// abbreviationModel is an entry of an abbreviations provider attribute such as resource_type_abbreviations
+type abbreviationModel struct {
+	Shortcode types.String `tfsdk:"shortcode"`
+	Char      types.String `tfsdk:"char"`
+}
+
+// abbreviationAttributeTypes are the attribute types of an abbreviations entry
+var abbreviationAttributeTypes = map[string]attr.Type{
+	"shortcode": types.StringType,
+	"char":      types.StringType,
+}
+
+// abbreviationsSchemaAttribute returns the schema of an abbreviations provider attribute keyed by fullname
+func abbreviationsSchemaAttribute(description string) schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Optional:    true,
+		Description: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"shortcode": schema.StringAttribute{
+					Required:    true,
+					Description: "Shortcode used by {component:short} placeholders.",
+				},
+				"char": schema.StringAttribute{
+					Optional:    true,
+					Description: "Single character used by {component:char} placeholders. Defaults to the first character of the shortcode.",
+				},
+			},
+		},
+	}
+}
+
+// abbreviationsFromMap converts an abbreviations provider attribute into abbreviations keyed by fullname
+func abbreviationsFromMap(ctx context.Context, abbreviations types.Map) (map[string]abbreviation, diag.Diagnostics) {
+	result := make(map[string]abbreviation)
+	if abbreviations.IsNull() || abbreviations.IsUnknown() {
+		return result, nil
+	}
+
+	var models map[string]abbreviationModel
+	diags := abbreviations.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return result, diags
+	}
+	for fullname, model := range models {
+		result[fullname] = abbreviation{
+			Shortcode: model.Shortcode.ValueString(),
+			Char:      model.Char.ValueString(),
+		}
+	}
+	return result, diags
+}
+
+// abbreviationsToMap converts abbreviations keyed by fullname into an abbreviations provider attribute
+func abbreviationsToMap(ctx context.Context, abbreviations map[string]abbreviation) (types.Map, diag.Diagnostics) {
+	models := make(map[string]abbreviationModel, len(abbreviations))
+	for fullname, abbr := range abbreviations {
+		model := abbreviationModel{
+			Shortcode: types.StringValue(abbr.Shortcode),
+			Char:      types.StringNull(),
+		}
+		if abbr.Char != "" {
+			model.Char = types.StringValue(abbr.Char)
+		}
+		models[fullname] = model
+	}
+	return types.MapValueFrom(ctx, types.ObjectType{AttrTypes: abbreviationAttributeTypes}, models)
+}
+
+// abbreviationsFromJSON converts the JSON representation of abbreviations, as saved in the configuration file, into an
+// abbreviations provider attribute
+func abbreviationsFromJSON(ctx context.Context, raw interface{}) (types.Map, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return types.MapNull(types.ObjectType{AttrTypes: abbreviationAttributeTypes}), err
+	}
+	var abbreviations map[string]abbreviation
+	if err := json.Unmarshal(data, &abbreviations); err != nil {
+		return types.MapNull(types.ObjectType{AttrTypes: abbreviationAttributeTypes}), err
+	}
+	abbreviationsMap, diags := abbreviationsToMap(ctx, abbreviations)
+	if diags.HasError() {
+		return types.MapNull(types.ObjectType{AttrTypes: abbreviationAttributeTypes}), fmt.Errorf("%s", diags.Errors()[0].Summary())
+	}
+	return abbreviationsMap, nil
+}
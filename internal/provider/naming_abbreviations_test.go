@@ -0,0 +1,346 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBuiltinAbbreviationCatalogs(t *testing.T) {
+	testCases := map[string]struct {
+		catalogs []string
+		// prefixes of the fullnames in each catalog
+		prefixes map[string]string
+		// uniqueShortcodes requires the shortcodes to be unique within each catalog
+		uniqueShortcodes bool
+	}{
+		"resource_type": {
+			catalogs: []string{"aws_waf", "azure_caf", "gcp"},
+			prefixes: map[string]string{"azure_caf": "azurerm_", "aws_waf": "aws_", "gcp": "google_"},
+		},
+		"region": {
+			catalogs:         []string{"aws", "azure", "gcp"},
+			uniqueShortcodes: true,
+		},
+		"location": {
+			catalogs:         []string{"aws", "azure", "gcp"},
+			uniqueShortcodes: true,
+		},
+	}
+	shortcodePattern := regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+	for component, testCase := range testCases {
+		t.Run(component, func(t *testing.T) {
+			var names []string
+			seen := make(map[string]string)
+			for _, catalog := range builtinAbbreviationCatalogs[component] {
+				names = append(names, catalog.Name)
+				if catalog.Version == "" || catalog.Description == "" || catalog.Source == "" {
+					t.Errorf("abbreviation catalog %s must have a version, description and source", catalog.Name)
+				}
+				for key := range catalog.index {
+					if other, ok := seen[key]; ok {
+						t.Errorf("%s is abbreviated by both %s and %s", key, other, catalog.Name)
+					}
+					seen[key] = catalog.Name
+				}
+				shortcodes := make(map[string]string)
+				for fullname, abbr := range catalog.Abbreviations {
+					if !strings.HasPrefix(fullname, testCase.prefixes[catalog.Name]) {
+						t.Errorf("%s doesn't belong in abbreviation catalog %s", fullname, catalog.Name)
+					}
+					if !shortcodePattern.MatchString(abbr.Shortcode) {
+						t.Errorf("shortcode %q of %s must be lower case letters and digits", abbr.Shortcode, fullname)
+					}
+					if utf8.RuneCountInString(abbr.Char) != 1 {
+						t.Errorf("char %q of %s must be a single character", abbr.Char, fullname)
+					}
+					if other, ok := shortcodes[abbr.Shortcode]; ok && testCase.uniqueShortcodes {
+						t.Errorf("shortcode %q of %s is also used by %s in %s", abbr.Shortcode, fullname, other, catalog.Name)
+					}
+					shortcodes[abbr.Shortcode] = fullname
+				}
+			}
+			if !reflect.DeepEqual(names, testCase.catalogs) {
+				t.Errorf("expected catalogs %v, got %v", testCase.catalogs, names)
+			}
+		})
+	}
+}
+
+func TestLoadAbbreviationCatalogs_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		data    string
+		message string
+	}{
+		"invalid json":      {`{"name": "custom",`, "abbreviation catalog custom.json is invalid"},
+		"wrong name":        {`{"name": "other", "version": "1.0.0"}`, "abbreviation catalog custom.json must be named \"custom\", got \"other\""},
+		"missing version":   {`{"name": "custom"}`, "abbreviation catalog custom has no version"},
+		"missing shortcode": {`{"name": "custom", "version": "1.0.0", "abbreviations": {"custom_type": {"char": "c"}}}`, "the abbreviation of \"custom_type\" has no shortcode"},
+		"duplicate alias":   {`{"name": "custom", "version": "1.0.0", "abbreviations": {"westeurope": {"shortcode": "weu"}, "northeurope": {"shortcode": "neu", "aliases": ["West Europe"]}}}`, "is abbreviated more than once"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			files := fstest.MapFS{"abbreviations/custom.json": &fstest.MapFile{Data: []byte(testCase.data)}}
+			_, err := loadAbbreviationCatalogs(files, "abbreviations")
+			if err == nil || !strings.Contains(err.Error(), testCase.message) {
+				t.Errorf("expected error containing %q, got %v", testCase.message, err)
+			}
+		})
+	}
+}
+
+func TestLookupAbbreviation(t *testing.T) {
+	ctx := context.Background()
+	configured, diags := abbreviationsToMap(ctx, map[string]abbreviation{
+		"azurerm_key_vault": {Shortcode: "vault"},
+		"custom_widget":     {Shortcode: "wdg", Char: "x"},
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to create abbreviations: %v", diags)
+	}
+	configuredRegions, diags := abbreviationsToMap(ctx, map[string]abbreviation{
+		"West Europe": {Shortcode: "we"},
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to create abbreviations: %v", diags)
+	}
+	config := resourcenamingtoolProviderModel{ResourceTypeAbbreviations: configured, RegionAbbreviations: configuredRegions}
+	if diags := decodeAbbreviations(ctx, &config); diags.HasError() {
+		t.Fatalf("failed to decode abbreviations: %v", diags)
+	}
+	azureCAF := testAbbreviationOrigin(t, "resource_type", "azure_caf")
+	azure := testAbbreviationOrigin(t, "region", "azure")
+	aws := testAbbreviationOrigin(t, "region", "aws")
+	gcp := testAbbreviationOrigin(t, "region", "gcp")
+
+	testCases := map[string]struct {
+		component string
+		fullname  string
+		expected  abbreviation
+		origin    string
+		found     bool
+	}{
+		"built-in":          {"resource_type", "azurerm_resource_group", abbreviation{Shortcode: "rg", Char: "r"}, azureCAF, true},
+		"provider override": {"resource_type", "azurerm_key_vault", abbreviation{Shortcode: "vault", Char: "v"}, "resource_type_abbreviations", true},
+		"provider addition": {"resource_type", "custom_widget", abbreviation{Shortcode: "wdg", Char: "x"}, "resource_type_abbreviations", true},
+		"unknown":           {"resource_type", "custom_gadget", abbreviation{}, "", false},
+		"not abbreviated":   {"environment", "production", abbreviation{}, "", false},
+		"region name":       {"region", "germanywestcentral", abbreviation{Shortcode: "gwc", Char: "g"}, azure, true},
+		"region display":    {"region", "Germany West Central", abbreviation{Shortcode: "gwc", Char: "g"}, azure, true},
+		"region alias":      {"region", "Europe (Ireland)", abbreviation{Shortcode: "euw1", Char: "e"}, aws, true},
+		"region code":       {"region", "europe-west1", abbreviation{Shortcode: "euw1", Char: "e"}, gcp, true},
+		"region override":   {"region", "westeurope", abbreviation{Shortcode: "we", Char: "w"}, "region_abbreviations", true},
+		"location":          {"location", "Sweden Central", abbreviation{Shortcode: "sdc", Char: "s"}, azure, true},
+		"unknown region":    {"region", "moon-base-1", abbreviation{}, "", false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			abbr, origin, found := lookupAbbreviation(config, testCase.component, testCase.fullname)
+			if found != testCase.found || abbr != testCase.expected || origin != testCase.origin {
+				t.Errorf("expected %+v from %q (%t), got %+v from %q (%t)", testCase.expected, testCase.origin, testCase.found, abbr, origin, found)
+			}
+		})
+	}
+}
+
+func TestDecodeAbbreviations_Malformed(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{"azurerm_resource_group": "rg-{basename}-{region:short}"})
+	config.RegionAbbreviations = types.MapValueMust(types.StringType, map[string]attr.Value{"westeurope": types.StringValue("we")})
+
+	_, diags := generateResourceName(context.Background(), testNamingParameters(t, map[string]map[string]string{
+		"resource_type": {"fullname": "azurerm_resource_group"},
+	}), config)
+	if !diags.HasError() {
+		t.Fatal("expected an error for malformed region abbreviations")
+	}
+	if diags.Errors()[0].Summary() != "Invalid Abbreviations" || !strings.Contains(diags.Errors()[0].Detail(), "The region_abbreviations provider attribute cannot be read") {
+		t.Errorf("expected Invalid Abbreviations error for region_abbreviations, got %s: %s", diags.Errors()[0].Summary(), diags.Errors()[0].Detail())
+	}
+}
+
+func TestAbbreviationValidate(t *testing.T) {
+	testCases := map[string]struct {
+		abbreviation abbreviation
+		problems     []string
+	}{
+		"valid": {
+			abbreviation: abbreviation{Shortcode: "we", Char: "w"},
+		},
+		"empty shortcode": {
+			abbreviation: abbreviation{},
+			problems:     []string{`The shortcode of "westeurope" in region_abbreviations must not be empty`},
+		},
+		"every problem is reported": {
+			abbreviation: abbreviation{Char: "{w"},
+			problems: []string{
+				`The shortcode of "westeurope" in region_abbreviations must not be empty`,
+				`The char "{w" of "westeurope" in region_abbreviations must be a single character`,
+				`The char "{w" of "westeurope" in region_abbreviations contains "{", which is reserved for naming patterns`,
+			},
+		},
+		"metacharacters in shortcode and char": {
+			abbreviation: abbreviation{Shortcode: "w]", Char: "["},
+			problems: []string{
+				`The shortcode "w]" of "westeurope" in region_abbreviations contains "]", which is reserved for naming patterns`,
+				`The char "[" of "westeurope" in region_abbreviations contains "[", which is reserved for naming patterns`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			problems := testCase.abbreviation.validate("westeurope", "region_abbreviations")
+			if !reflect.DeepEqual(problems, testCase.problems) {
+				t.Errorf("expected %q, got %q", testCase.problems, problems)
+			}
+		})
+	}
+}
+
+func TestAbbreviationsFromJSON(t *testing.T) {
+	ctx := context.Background()
+	configured, diags := abbreviationsToMap(ctx, map[string]abbreviation{"custom_widget": {Shortcode: "wdg"}})
+	if diags.HasError() {
+		t.Fatalf("failed to create abbreviations: %v", diags)
+	}
+	data, err := json.Marshal(resourcenamingtoolProviderModel{ResourceTypeAbbreviations: configured})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var output map[string]interface{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	loaded, err := abbreviationsFromJSON(ctx, output["ResourceTypeAbbreviations"])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !loaded.Equal(configured) {
+		t.Errorf("expected %s, got %s", configured, loaded)
+	}
+}
+
+func TestGenerateResourceNameDetails_Abbreviations(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{"default": "{resource_type:short}{resource_type:char}-{basename}"})
+	config.DefaultResourceType = testComponentValue(t, "azurerm_key_vault", "", "")
+	config.ResourceTypeAbbreviations = types.MapNull(types.ObjectType{AttrTypes: abbreviationAttributeTypes})
+	azureCAF := testAbbreviationOrigin(t, "resource_type", "azure_caf")
+
+	testCases := map[string]struct {
+		resourceType map[string]string
+		name         string
+		derivations  []string
+	}{
+		"function call": {
+			resourceType: map[string]string{"fullname": "azurerm_resource_group"},
+			name:         "rgr-example",
+			derivations:  []string{"shortcode not set, using the abbreviation from " + azureCAF, "char not set, using the abbreviation from " + azureCAF},
+		},
+		"function call shortcode": {
+			resourceType: map[string]string{"fullname": "azurerm_resource_group", "shortcode": "grp"},
+			name:         "grpr-example",
+			derivations:  []string{"", "char not set, using the abbreviation from " + azureCAF},
+		},
+		"provider default": {
+			name:        "kvk-example",
+			derivations: []string{"shortcode not set, using the abbreviation from " + azureCAF, "char not set, using the abbreviation from " + azureCAF},
+		},
+		"not in catalog": {
+			resourceType: map[string]string{"fullname": "custom_widget"},
+			name:         "custom_widgetc-example",
+			derivations:  []string{"shortcode not set, using the fullname", "char not set, using the first character of the fullname"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			arguments := map[string]map[string]string{}
+			if testCase.resourceType != nil {
+				arguments["resource_type"] = testCase.resourceType
+			}
+			details, diags := generateResourceNameDetails(context.Background(), testNamingParameters(t, arguments), config)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if details.Name != testCase.name {
+				t.Errorf("expected name %q, got %q", testCase.name, details.Name)
+			}
+			for i, derivation := range testCase.derivations {
+				if details.Placeholders[i].Derivation != derivation {
+					t.Errorf("expected derivation %q of %s, got %q", derivation, details.Placeholders[i].Placeholder, details.Placeholders[i].Derivation)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateResourceNameDetails_RegionAbbreviations(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{"default": "{basename}-{region:short}-{location:char}"})
+	config.DefaultRegion = testComponentValue(t, "Germany West Central", "", "")
+	azure := testAbbreviationOrigin(t, "region", "azure")
+	aws := testAbbreviationOrigin(t, "region", "aws")
+
+	testCases := map[string]struct {
+		arguments   map[string]map[string]string
+		name        string
+		derivations []string
+	}{
+		"provider default": {
+			name: "example-gwc-g",
+			derivations: []string{"", "shortcode not set, using the abbreviation from " + azure,
+				"location not set, using region; char not set, using the abbreviation from " + azure},
+		},
+		"function call": {
+			arguments: map[string]map[string]string{"region": {"fullname": "eu-central-1"}, "location": {"fullname": "Europe (Zurich)"}},
+			name:      "example-euc1-e",
+			derivations: []string{"", "shortcode not set, using the abbreviation from " + aws,
+				"char not set, using the abbreviation from " + aws},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			arguments := map[string]map[string]string{"resource_type": {"fullname": "custom_widget"}}
+			for component, value := range testCase.arguments {
+				arguments[component] = value
+			}
+			details, diags := generateResourceNameDetails(context.Background(), testNamingParameters(t, arguments), config)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if details.Name != testCase.name {
+				t.Errorf("expected name %q, got %q", testCase.name, details.Name)
+			}
+			for i, derivation := range testCase.derivations {
+				if details.Placeholders[i].Derivation != derivation {
+					t.Errorf("expected derivation %q of %s, got %q", derivation, details.Placeholders[i].Placeholder, details.Placeholders[i].Derivation)
+				}
+			}
+		})
+	}
+}
+
+// testAbbreviationOrigin returns the origin of the abbreviations of a built-in catalog of a component
+func testAbbreviationOrigin(t *testing.T, component, name string) string {
+	for _, catalog := range builtinAbbreviationCatalogs[component] {
+		if catalog.Name == name {
+			return "the " + catalog.String() + " catalog"
+		}
+	}
+	t.Fatalf("abbreviation catalog %s of %s not found", name, component)
+	return ""
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// patternSetFiles holds the built-in pattern sets, one JSON file per set named after the set
+//
+//go:embed catalogs/patterns/*.json
+var patternSetFiles embed.FS
+
+// catalogInfo identifies a built-in catalog. The version is raised whenever an entry of the catalog changes, so changes
+// of generated names can be traced back to the catalog.
+type catalogInfo struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Source      string `json:"source"`
+}
+
+// String returns the name and version of the catalog, e.g. "azure_caf@1.0.0"
+func (c catalogInfo) String() string {
+	return c.Name + "@" + c.Version
+}
+
+// patternSet is a versioned catalog of built-in naming patterns, selected with the builtin_pattern_sets provider
+// attribute
+type patternSet struct {
+	catalogInfo
+	Patterns map[string]string `json:"patterns"`
+}
+
+// builtinPatternSets holds the built-in pattern sets keyed by name
+var builtinPatternSets = mustLoadPatternSets(patternSetFiles, "catalogs/patterns")
+
+// readCatalogs decodes every JSON file in dir with decode, which returns the catalogInfo of the decoded catalog. Every
+// catalog must be named after its file and carry a version, kind names the catalogs in errors.
+func readCatalogs(files fs.FS, dir, kind string, decode func(data []byte) (catalogInfo, error)) error {
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		data, err := fs.ReadFile(files, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		info, err := decode(data)
+		if err != nil {
+			return fmt.Errorf("%s %s is invalid: %w", kind, entry.Name(), err)
+		}
+		if name := strings.TrimSuffix(entry.Name(), ".json"); info.Name != name {
+			return fmt.Errorf("%s %s must be named %q, got %q", kind, entry.Name(), name, info.Name)
+		}
+		if info.Version == "" {
+			return fmt.Errorf("%s %s has no version", kind, info.Name)
+		}
+	}
+	return nil
+}
+
+// loadPatternSets reads the pattern sets in dir, every set must be named after its file and carry a version
+func loadPatternSets(files fs.FS, dir string) (map[string]patternSet, error) {
+	sets := make(map[string]patternSet)
+	err := readCatalogs(files, dir, "pattern set", func(data []byte) (catalogInfo, error) {
+		var set patternSet
+		if err := json.Unmarshal(data, &set); err != nil {
+			return catalogInfo{}, err
+		}
+		sets[set.Name] = set
+		return set.catalogInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sets, nil
+}
+
+// mustLoadPatternSets loads the embedded pattern sets, which are validated by the tests
+func mustLoadPatternSets(files fs.FS, dir string) map[string]patternSet {
+	sets, err := loadPatternSets(files, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sets
+}
+
+// patternSetNames returns the names of the built-in pattern sets in alphabetical order
+func patternSetNames() []string {
+	names := make([]string, 0, len(builtinPatternSets))
+	for name := range builtinPatternSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectedPatternSets returns the names of the pattern sets selected with the builtin_pattern_sets provider attribute
+func selectedPatternSets(config resourcenamingtoolProviderModel) []string {
+	var names []string
+	if config.BuiltinPatternSets.IsNull() || config.BuiltinPatternSets.IsUnknown() {
+		return names
+	}
+	for _, value := range config.BuiltinPatternSets.Elements() {
+		if strVal, ok := value.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
+			names = append(names, strVal.ValueString())
+		}
+	}
+	return names
+}
+
+// builtinNamingPatterns returns the naming patterns of the selected pattern sets keyed by resource type, and the
+// pattern set each pattern came from. When several sets have a pattern for the same key, the set listed last wins.
+func builtinNamingPatterns(config resourcenamingtoolProviderModel) (map[string]string, map[string]patternSet) {
+	patterns := make(map[string]string)
+	sources := make(map[string]patternSet)
+	for _, name := range selectedPatternSets(config) {
+		set, ok := builtinPatternSets[name]
+		if !ok {
+			continue
+		}
+		for key, pattern := range set.Patterns {
+			patterns[key] = pattern
+			sources[key] = set
+		}
+	}
+	return patterns, sources
+}
@@ -0,0 +1,122 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBuiltinPatternSets(t *testing.T) {
+	prefixes := map[string]string{
+		"azure_caf": "azurerm_",
+		"aws_waf":   "aws_",
+		"gcp":       "google_",
+	}
+	if names := patternSetNames(); !reflect.DeepEqual(names, []string{"aws_waf", "azure_caf", "gcp"}) {
+		t.Fatalf("unexpected pattern sets: %v", names)
+	}
+
+	for name, set := range builtinPatternSets {
+		t.Run(name, func(t *testing.T) {
+			if set.Version == "" || set.Description == "" || set.Source == "" {
+				t.Errorf("pattern set %s must have a version, description and source", name)
+			}
+			if len(set.Patterns) == 0 {
+				t.Fatalf("pattern set %s has no patterns", name)
+			}
+			for resourceType, pattern := range set.Patterns {
+				if !strings.HasPrefix(resourceType, prefixes[name]) {
+					t.Errorf("resource type %s doesn't belong in pattern set %s", resourceType, name)
+				}
+				parsed, err := parseNamingPattern(pattern)
+				if err != nil {
+					t.Errorf("pattern %q of %s is invalid: %s", pattern, resourceType, err)
+					continue
+				}
+				if len(parsed.placeholders()) == 0 {
+					t.Errorf("pattern %q of %s has no placeholders", pattern, resourceType)
+				}
+			}
+		})
+	}
+}
+
+func TestBuiltinPatternSets_Placeholders(t *testing.T) {
+	for name, set := range builtinPatternSets {
+		t.Run(name, func(t *testing.T) {
+			for resourceType, pattern := range set.Patterns {
+				parsed, err := parseNamingPattern(pattern)
+				if err != nil {
+					t.Errorf("pattern %q of %s is invalid: %s", pattern, resourceType, err)
+					continue
+				}
+				// Every placeholder must resolve to a built-in component, the patterns can't rely on additional_components
+				for _, node := range parsed.placeholders() {
+					components := []string{node.Name}
+					if isSeparatorPlaceholder(node) {
+						continue
+					}
+					if isHashPlaceholder(node) {
+						components = hashPlaceholderComponents(node, parsed)
+					}
+					for _, component := range components {
+						if _, ok := lookupComponentDefinition(canonicalComponentName(component)); !ok {
+							t.Errorf("placeholder %s of %s references component %s, which is not a built-in component", node.Text, resourceType, component)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLoadPatternSets_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		data    string
+		message string
+	}{
+		"invalid json":    {`{"name": "custom",`, "pattern set custom.json is invalid"},
+		"wrong name":      {`{"name": "other", "version": "1.0.0"}`, "pattern set custom.json must be named \"custom\", got \"other\""},
+		"missing version": {`{"name": "custom"}`, "pattern set custom has no version"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			files := fstest.MapFS{"patterns/custom.json": &fstest.MapFile{Data: []byte(testCase.data)}}
+			_, err := loadPatternSets(files, "patterns")
+			if err == nil || !strings.Contains(err.Error(), testCase.message) {
+				t.Errorf("expected error containing %q, got %v", testCase.message, err)
+			}
+		})
+	}
+}
+
+func TestBuiltinNamingPatterns(t *testing.T) {
+	config := resourcenamingtoolProviderModel{
+		BuiltinPatternSets: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("azure_caf"), types.StringValue("gcp")}),
+	}
+	patterns, sources := builtinNamingPatterns(config)
+	if len(patterns) != len(builtinPatternSets["azure_caf"].Patterns)+len(builtinPatternSets["gcp"].Patterns) {
+		t.Errorf("expected the patterns of azure_caf and gcp, got %d patterns", len(patterns))
+	}
+	if patterns["azurerm_key_vault"] != builtinPatternSets["azure_caf"].Patterns["azurerm_key_vault"] {
+		t.Errorf("expected the azure_caf pattern of azurerm_key_vault, got %q", patterns["azurerm_key_vault"])
+	}
+	if _, ok := patterns["aws_s3_bucket"]; ok {
+		t.Errorf("expected no patterns of aws_waf")
+	}
+	if source := sources["google_storage_bucket"].String(); source != "gcp@"+builtinPatternSets["gcp"].Version {
+		t.Errorf("expected google_storage_bucket to come from gcp, got %s", source)
+	}
+
+	patterns, _ = builtinNamingPatterns(resourcenamingtoolProviderModel{BuiltinPatternSets: types.ListNull(types.StringType)})
+	if len(patterns) != 0 {
+		t.Errorf("expected no built-in patterns without pattern sets, got %v", patterns)
+	}
+}
@@ -0,0 +1,372 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// allowedComponentValues returns the allowed_values of the provider keyed by component name
+func allowedComponentValues(ctx context.Context, config resourcenamingtoolProviderModel) (map[string][]string, diag.Diagnostics) {
+	allowed := make(map[string][]string)
+	if config.AllowedValues.IsNull() || config.AllowedValues.IsUnknown() {
+		return allowed, nil
+	}
+	diags := config.AllowedValues.ElementsAs(ctx, &allowed, false)
+	return allowed, diags
+}
+
+// componentValueStrings returns the fullname, shortcode and char of a component value keyed by attribute, the map is
+// empty when the component is null or unknown
+func componentValueStrings(ctx context.Context, value ComponentValueObject) map[string]string {
+	values := make(map[string]string)
+	if value.IsNull() || value.IsUnknown() {
+		return values
+	}
+	if fullname, diags := value.GetFullname(ctx); !diags.HasError() && fullname != "" {
+		values["fullname"] = fullname
+	}
+	if shortcode, diags := value.GetShortcode(ctx); !diags.HasError() && shortcode != "" {
+		values["shortcode"] = shortcode
+	}
+	if char, diags := value.GetChar(ctx); !diags.HasError() && char != "" {
+		values["char"] = char
+	}
+	return values
+}
+
+// checkAllowedValue returns an error when a component has allowed values and one of its representations isn't
+// allowed. The fullname and the shortcode must be one of the allowed values, where a fullname is also allowed when its
+// abbreviation in the catalogs is, and a missing shortcode is taken from the abbreviation catalogs. The char must be
+// the char of one of the allowed values.
+func checkAllowedValue(config resourcenamingtoolProviderModel, allowed map[string][]string, componentName string, values map[string]string) error {
+	permitted, ok := allowed[componentName]
+	if !ok || len(values) == 0 {
+		return nil
+	}
+
+	permittedValues := make(map[string]bool, len(permitted))
+	permittedChars := make(map[string]bool, len(permitted))
+	for _, value := range permitted {
+		permittedValues[value] = true
+		permittedChars[runePrefix(value, 1)] = true
+		if abbr, _, ok := lookupAbbreviation(config, componentName, value); ok {
+			permittedChars[abbr.Char] = true
+		}
+	}
+
+	fullname, shortcode := values["fullname"], values["shortcode"]
+	abbr, _, abbreviated := lookupAbbreviation(config, componentName, fullname)
+	if fullname != "" && !permittedValues[fullname] && !(abbreviated && permittedValues[abbr.Shortcode]) {
+		return fmt.Errorf("fullname %q of component %s is not one of the allowed values: %s", fullname, componentName, strings.Join(permitted, ", "))
+	}
+	if shortcode == "" && abbreviated {
+		shortcode = abbr.Shortcode
+	}
+	if shortcode != "" && !permittedValues[shortcode] {
+		return fmt.Errorf("shortcode %q of component %s is not one of the allowed values: %s", shortcode, componentName, strings.Join(permitted, ", "))
+	}
+	if char := values["char"]; char != "" && !permittedChars[char] {
+		return fmt.Errorf("char %q of component %s is not the char of one of the allowed values: %s", char, componentName, strings.Join(permitted, ", "))
+	}
+	return nil
+}
+
+// checkCallComponentValues checks the components passed in a function call, including its additional_components,
+// against the component_rules and allowed_values of the provider. The provider defaults are checked when the
+// configuration is validated.
+func checkCallComponentValues(ctx context.Context, params ResourceNamingParametersValue, config resourcenamingtoolProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	allowed, allowedDiags := allowedComponentValues(ctx, config)
+	diags.Append(allowedDiags...)
+	rules, rulesDiags := componentRulesFromMap(ctx, config.ComponentRules)
+	diags.Append(rulesDiags...)
+	if diags.HasError() || (len(allowed) == 0 && len(rules) == 0) {
+		return diags
+	}
+
+	checkValues := func(componentName string, values map[string]string) {
+		for _, err := range checkComponentRules(rules, componentName, values) {
+			diags.AddError("Invalid Component Value", "The "+err.Error())
+		}
+		if err := checkAllowedValue(config, allowed, componentName, values); err != nil {
+			diags.AddError("Value Not Allowed", "The "+err.Error())
+		}
+	}
+
+	for _, definition := range componentRegistry {
+		value, valueDiags := params.GetComponentValue(ctx, definition.Name)
+		if valueDiags.HasError() {
+			continue
+		}
+		checkValues(definition.Name, componentValueStrings(ctx, value))
+	}
+
+	groups := getAdditionalComponentGroups(ctx, params)
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		checkValues(name, groups[name])
+	}
+	return diags
+}
+
+// componentRulesWildcard is the component_rules key of the rules that apply to every component
+const componentRulesWildcard = "*"
+
+// valueRule restricts one representation of a component value, the fullname, shortcode or char
+type valueRule struct {
+	// Pattern is a regular expression the value must match, anchor it with ^ and $ to match the whole value
+	Pattern   string `json:"pattern,omitempty"`
+	MinLength int    `json:"min_length,omitempty"`
+	MaxLength int    `json:"max_length,omitempty"`
+}
+
+// componentRule holds the rules of the representations of a component, a nil rule doesn't restrict the representation
+type componentRule struct {
+	Fullname  *valueRule `json:"fullname,omitempty"`
+	Shortcode *valueRule `json:"shortcode,omitempty"`
+	Char      *valueRule `json:"char,omitempty"`
+}
+
+// valueRuleModel is the Terraform representation of a valueRule
+type valueRuleModel struct {
+	Pattern   types.String `tfsdk:"pattern"`
+	MinLength types.Int64  `tfsdk:"min_length"`
+	MaxLength types.Int64  `tfsdk:"max_length"`
+}
+
+// componentRuleModel is the Terraform representation of a component_rules entry
+type componentRuleModel struct {
+	Fullname  types.Object `tfsdk:"fullname"`
+	Shortcode types.Object `tfsdk:"shortcode"`
+	Char      types.Object `tfsdk:"char"`
+}
+
+// valueRuleAttributeTypes are the attribute types of a valueRule
+var valueRuleAttributeTypes = map[string]attr.Type{
+	"pattern":    types.StringType,
+	"min_length": types.Int64Type,
+	"max_length": types.Int64Type,
+}
+
+// componentRuleAttributeTypes are the attribute types of a component_rules entry
+var componentRuleAttributeTypes = map[string]attr.Type{
+	"fullname":  types.ObjectType{AttrTypes: valueRuleAttributeTypes},
+	"shortcode": types.ObjectType{AttrTypes: valueRuleAttributeTypes},
+	"char":      types.ObjectType{AttrTypes: valueRuleAttributeTypes},
+}
+
+// representations returns the rules of the component keyed by representation, leaving out the nil rules
+func (r componentRule) representations() map[string]*valueRule {
+	rules := make(map[string]*valueRule)
+	for attribute, rule := range map[string]*valueRule{"fullname": r.Fullname, "shortcode": r.Shortcode, "char": r.Char} {
+		if rule != nil {
+			rules[attribute] = rule
+		}
+	}
+	return rules
+}
+
+// validate checks that the rule is consistent and its pattern is a valid regular expression
+func (r valueRule) validate() error {
+	if r.MinLength < 0 || r.MaxLength < 0 {
+		return fmt.Errorf("min_length and max_length cannot be negative")
+	}
+	if r.MinLength != 0 && r.MaxLength != 0 && r.MinLength > r.MaxLength {
+		return fmt.Errorf("min_length %d is greater than max_length %d", r.MinLength, r.MaxLength)
+	}
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("pattern %q is not a valid regular expression: %s", r.Pattern, err.Error())
+		}
+	}
+	return nil
+}
+
+// check returns the violations of the rule by a value, described as "is 13 characters long, exceeding the max_length
+// of 12". The length is counted in characters.
+func (r valueRule) check(value string) []string {
+	var violations []string
+	length := utf8.RuneCountInString(value)
+	if r.MaxLength > 0 && length > r.MaxLength {
+		violations = append(violations, fmt.Sprintf("is %d characters long, exceeding the max_length of %d", length, r.MaxLength))
+	}
+	if r.MinLength > 0 && length < r.MinLength {
+		violations = append(violations, fmt.Sprintf("is %d characters long, below the min_length of %d", length, r.MinLength))
+	}
+	if r.Pattern != "" {
+		if re, err := regexp.Compile(r.Pattern); err == nil && !re.MatchString(value) {
+			violations = append(violations, fmt.Sprintf("doesn't match the pattern %s", r.Pattern))
+		}
+	}
+	return violations
+}
+
+// checkComponentRules returns an error for every rule of the component, and of the "*" key that applies to every
+// component, that is violated by one of its values. Representations without a value are not checked.
+func checkComponentRules(rules map[string]componentRule, componentName string, values map[string]string) []error {
+	var errs []error
+	for _, key := range []string{componentRulesWildcard, componentName} {
+		rule, ok := rules[key]
+		if !ok {
+			continue
+		}
+		representations := rule.representations()
+		for _, attribute := range []string{"fullname", "shortcode", "char"} {
+			valueRule, ok := representations[attribute]
+			if !ok || values[attribute] == "" {
+				continue
+			}
+			for _, violation := range valueRule.check(values[attribute]) {
+				errs = append(errs, fmt.Errorf("%s %q of component %s %s", attribute, values[attribute], componentName, violation))
+			}
+		}
+	}
+	return errs
+}
+
+// componentRulesFromMap converts the component_rules provider attribute into component rules keyed by component name
+func componentRulesFromMap(ctx context.Context, rules types.Map) (map[string]componentRule, diag.Diagnostics) {
+	result := make(map[string]componentRule)
+	if rules.IsNull() || rules.IsUnknown() {
+		return result, nil
+	}
+
+	var models map[string]componentRuleModel
+	diags := rules.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return result, diags
+	}
+	for name, model := range models {
+		var rule componentRule
+		for _, representation := range []struct {
+			object types.Object
+			rule   **valueRule
+		}{
+			{model.Fullname, &rule.Fullname},
+			{model.Shortcode, &rule.Shortcode},
+			{model.Char, &rule.Char},
+		} {
+			if representation.object.IsNull() || representation.object.IsUnknown() {
+				continue
+			}
+			var valueModel valueRuleModel
+			diags.Append(representation.object.As(ctx, &valueModel, basetypes.ObjectAsOptions{})...)
+			*representation.rule = &valueRule{
+				Pattern:   valueModel.Pattern.ValueString(),
+				MinLength: int(valueModel.MinLength.ValueInt64()),
+				MaxLength: int(valueModel.MaxLength.ValueInt64()),
+			}
+		}
+		result[name] = rule
+	}
+	return result, diags
+}
+
+// componentRulesToMap converts component rules keyed by component name into the component_rules provider attribute
+func componentRulesToMap(ctx context.Context, rules map[string]componentRule) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	valueRuleToObject := func(rule *valueRule) types.Object {
+		if rule == nil {
+			return types.ObjectNull(valueRuleAttributeTypes)
+		}
+		model := valueRuleModel{
+			Pattern:   types.StringNull(),
+			MinLength: types.Int64Null(),
+			MaxLength: types.Int64Null(),
+		}
+		if rule.Pattern != "" {
+			model.Pattern = types.StringValue(rule.Pattern)
+		}
+		if rule.MinLength != 0 {
+			model.MinLength = types.Int64Value(int64(rule.MinLength))
+		}
+		if rule.MaxLength != 0 {
+			model.MaxLength = types.Int64Value(int64(rule.MaxLength))
+		}
+		object, objectDiags := types.ObjectValueFrom(ctx, valueRuleAttributeTypes, model)
+		diags.Append(objectDiags...)
+		return object
+	}
+
+	models := make(map[string]componentRuleModel, len(rules))
+	for name, rule := range rules {
+		models[name] = componentRuleModel{
+			Fullname:  valueRuleToObject(rule.Fullname),
+			Shortcode: valueRuleToObject(rule.Shortcode),
+			Char:      valueRuleToObject(rule.Char),
+		}
+	}
+	rulesMap, mapDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: componentRuleAttributeTypes}, models)
+	diags.Append(mapDiags...)
+	return rulesMap, diags
+}
+
+// componentRulesFromJSON converts the JSON representation of the component rules, as saved in the configuration file,
+// into the component_rules provider attribute
+func componentRulesFromJSON(ctx context.Context, raw interface{}) (types.Map, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return types.MapNull(types.ObjectType{AttrTypes: componentRuleAttributeTypes}), err
+	}
+	var rules map[string]componentRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return types.MapNull(types.ObjectType{AttrTypes: componentRuleAttributeTypes}), err
+	}
+	rulesMap, diags := componentRulesToMap(ctx, rules)
+	if diags.HasError() {
+		return types.MapNull(types.ObjectType{AttrTypes: componentRuleAttributeTypes}), fmt.Errorf("%s", diags.Errors()[0].Summary())
+	}
+	return rulesMap, nil
+}
+
+// componentRulesSchemaAttribute returns the schema of the component_rules provider attribute
+func componentRulesSchemaAttribute() schema.MapNestedAttribute {
+	valueRuleAttribute := func(representation string) schema.SingleNestedAttribute {
+		return schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: fmt.Sprintf("Rules of the %s of the component.", representation),
+			Attributes: map[string]schema.Attribute{
+				"pattern": schema.StringAttribute{
+					Optional:    true,
+					Description: fmt.Sprintf("Regular expression the %s must match (e.g., '^[a-z0-9]{2,4}$'). Anchor it with ^ and $ to match the whole value.", representation),
+				},
+				"min_length": schema.Int64Attribute{
+					Optional:    true,
+					Description: fmt.Sprintf("Minimum number of characters of the %s.", representation),
+				},
+				"max_length": schema.Int64Attribute{
+					Optional:    true,
+					Description: fmt.Sprintf("Maximum number of characters of the %s.", representation),
+				},
+			},
+		}
+	}
+	return schema.MapNestedAttribute{
+		Optional:    true,
+		Description: "Validation rules of components keyed by component name, or \"*\" for rules that apply to every component (e.g., \"*\": { char = { max_length = 1 } }, \"basename\": { fullname = { max_length = 12 } }). Checked for the provider defaults when the configuration is validated and for the components of every function call.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"fullname":  valueRuleAttribute("fullname"),
+				"shortcode": valueRuleAttribute("shortcode"),
+				"char":      valueRuleAttribute("char"),
+			},
+		},
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testAllowedValues returns the allowed_values provider attribute for the allowed values
+func testAllowedValues(t *testing.T, allowed map[string][]string) types.Map {
+	allowedMap, diags := types.MapValueFrom(context.Background(), types.ListType{ElemType: types.StringType}, allowed)
+	if diags.HasError() {
+		t.Fatalf("failed to create allowed values: %v", diags)
+	}
+	return allowedMap
+}
+
+func TestCheckAllowedValue(t *testing.T) {
+	config := resourcenamingtoolProviderModel{}
+	allowed := map[string][]string{
+		"environment": {"production", "prd", "development", "dev"},
+		"region":      {"weu", "neu"},
+		"department":  {"finance", "hr"},
+	}
+	testCases := map[string]struct {
+		component string
+		values    map[string]string
+		message   string
+	}{
+		"all representations":     {"environment", map[string]string{"fullname": "production", "shortcode": "prd", "char": "p"}, ""},
+		"fullname":                {"environment", map[string]string{"fullname": "prd"}, ""},
+		"char only":               {"department", map[string]string{"char": "f"}, ""},
+		"catalog shortcode":       {"region", map[string]string{"fullname": "West Europe"}, ""},
+		"catalog char":            {"region", map[string]string{"fullname": "West Europe", "char": "w"}, ""},
+		"additional component":    {"department", map[string]string{"fullname": "finance"}, ""},
+		"no allowed values":       {"basename", map[string]string{"fullname": "anything"}, ""},
+		"empty":                   {"environment", map[string]string{}, ""},
+		"typo in shortcode":       {"environment", map[string]string{"fullname": "prd", "shortcode": "prdo"}, "shortcode \"prdo\" of component environment is not one of the allowed values: production, prd, development, dev"},
+		"typo in fullname":        {"environment", map[string]string{"fullname": "prdo", "shortcode": "prd"}, "fullname \"prdo\" of component environment is not one of the allowed values: production, prd, development, dev"},
+		"typo in char":            {"environment", map[string]string{"fullname": "production", "shortcode": "prd", "char": "x"}, "char \"x\" of component environment is not the char of one of the allowed values: production, prd, development, dev"},
+		"unknown shortcode":       {"department", map[string]string{"fullname": "finance", "shortcode": "fin"}, "shortcode \"fin\" of component department is not one of the allowed values: finance, hr"},
+		"region without shortcut": {"region", map[string]string{"fullname": "eastus"}, "fullname \"eastus\" of component region is not one of the allowed values: weu, neu"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := checkAllowedValue(config, allowed, testCase.component, testCase.values)
+			if testCase.message == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != testCase.message {
+				t.Errorf("expected error %q, got %v", testCase.message, err)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_AllowedValues(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{"azurerm_resource_group": "rg-{basename}-{environment:short}-{department}"})
+	config.AllowedValues = testAllowedValues(t, map[string][]string{
+		"environment": {"development", "dev", "production", "prd"},
+		"department":  {"finance", "hr"},
+	})
+
+	testCases := map[string]struct {
+		arguments map[string]map[string]string
+		name      string
+		detail    string
+	}{
+		"allowed": {
+			arguments: map[string]map[string]string{
+				"environment":           {"fullname": "development", "shortcode": "dev"},
+				"additional_components": {"department.fullname": "hr"},
+			},
+			name: "rg-example-dev-hr",
+		},
+		"environment not allowed": {
+			arguments: map[string]map[string]string{
+				"environment":           {"fullname": "production", "shortcode": "prdo"},
+				"additional_components": {"department.fullname": "hr"},
+			},
+			detail: "The shortcode \"prdo\" of component environment is not one of the allowed values: development, dev, production, prd",
+		},
+		"additional component not allowed": {
+			arguments: map[string]map[string]string{
+				"additional_components": {"department.fullname": "marketing"},
+			},
+			detail: "The fullname \"marketing\" of component department is not one of the allowed values: finance, hr",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			arguments := map[string]map[string]string{"resource_type": {"fullname": "azurerm_resource_group"}}
+			for key, value := range testCase.arguments {
+				arguments[key] = value
+			}
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+			if testCase.detail == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				if result != testCase.name {
+					t.Errorf("expected name %q, got %q", testCase.name, result)
+				}
+				return
+			}
+			if !diags.HasError() {
+				t.Fatalf("expected an error, got %q", result)
+			}
+			if diags.Errors()[0].Summary() != "Value Not Allowed" || !strings.Contains(diags.Errors()[0].Detail(), testCase.detail) {
+				t.Errorf("expected Value Not Allowed error containing %q, got %s: %s", testCase.detail, diags.Errors()[0].Summary(), diags.Errors()[0].Detail())
+			}
+		})
+	}
+}
+
+func TestValueRuleValidate(t *testing.T) {
+	testCases := map[string]struct {
+		rule    valueRule
+		message string
+	}{
+		"valid":            {valueRule{Pattern: "^[a-z0-9]{2,4}$", MinLength: 2, MaxLength: 4}, ""},
+		"negative length":  {valueRule{MinLength: -1}, "min_length and max_length cannot be negative"},
+		"inverted lengths": {valueRule{MinLength: 5, MaxLength: 4}, "min_length 5 is greater than max_length 4"},
+		"invalid pattern":  {valueRule{Pattern: "^[a-z"}, "pattern \"^[a-z\" is not a valid regular expression"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.rule.validate()
+			if testCase.message == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), testCase.message) {
+				t.Errorf("expected error containing %q, got %v", testCase.message, err)
+			}
+		})
+	}
+}
+
+func TestCheckComponentRules(t *testing.T) {
+	rules := map[string]componentRule{
+		componentRulesWildcard: {Char: &valueRule{MinLength: 1, MaxLength: 1}},
+		"environment":          {Shortcode: &valueRule{Pattern: "^[a-z0-9]{2,4}$"}},
+		"basename":             {Fullname: &valueRule{MaxLength: 12}},
+	}
+	testCases := map[string]struct {
+		component string
+		values    map[string]string
+		messages  []string
+	}{
+		"valid":             {"environment", map[string]string{"fullname": "production", "shortcode": "prd", "char": "p"}, nil},
+		"shortcode pattern": {"environment", map[string]string{"shortcode": "PRD-1"}, []string{"shortcode \"PRD-1\" of component environment doesn't match the pattern ^[a-z0-9]{2,4}$"}},
+		"wildcard char":     {"department", map[string]string{"char": "finance"}, []string{"char \"finance\" of component department is 7 characters long, exceeding the max_length of 1"}},
+		"fullname length":   {"basename", map[string]string{"fullname": "customerportal", "char": "cp"}, []string{"char \"cp\" of component basename is 2 characters long, exceeding the max_length of 1", "fullname \"customerportal\" of component basename is 14 characters long, exceeding the max_length of 12"}},
+		"multi-byte":        {"basename", map[string]string{"fullname": "zürichportal"}, nil},
+		"not set":           {"environment", map[string]string{"fullname": "production"}, nil},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var messages []string
+			for _, err := range checkComponentRules(rules, testCase.component, testCase.values) {
+				messages = append(messages, err.Error())
+			}
+			if strings.Join(messages, "\n") != strings.Join(testCase.messages, "\n") {
+				t.Errorf("expected errors %q, got %q", testCase.messages, messages)
+			}
+		})
+	}
+}
+
+func TestComponentRulesFromJSON(t *testing.T) {
+	ctx := context.Background()
+	configured, diags := componentRulesToMap(ctx, map[string]componentRule{
+		componentRulesWildcard: {Char: &valueRule{MaxLength: 1}},
+		"environment":          {Shortcode: &valueRule{Pattern: "^[a-z0-9]{2,4}$", MinLength: 2}},
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to create component rules: %v", diags)
+	}
+	data, err := json.Marshal(resourcenamingtoolProviderModel{ComponentRules: configured})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var output map[string]interface{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	loaded, err := componentRulesFromJSON(ctx, output["ComponentRules"])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !loaded.Equal(configured) {
+		t.Errorf("expected %s, got %s", configured, loaded)
+	}
+}
+
+func TestGenerateResourceName_ComponentRules(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{"azurerm_resource_group": "rg-{basename}-{environment:short}"})
+	rules, diags := componentRulesToMap(context.Background(), map[string]componentRule{
+		"environment": {Shortcode: &valueRule{Pattern: "^[a-z0-9]{2,4}$"}},
+		"basename":    {Fullname: &valueRule{MaxLength: 12}},
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to create component rules: %v", diags)
+	}
+	config.ComponentRules = rules
+
+	testCases := map[string]struct {
+		arguments map[string]map[string]string
+		name      string
+		details   []string
+	}{
+		"valid": {
+			arguments: map[string]map[string]string{"basename": {"fullname": "portal"}},
+			name:      "rg-portal-prd",
+		},
+		"invalid values": {
+			arguments: map[string]map[string]string{
+				"basename":    {"fullname": "customerportal"},
+				"environment": {"fullname": "production", "shortcode": "production"},
+			},
+			details: []string{
+				"The fullname \"customerportal\" of component basename is 14 characters long, exceeding the max_length of 12",
+				"The shortcode \"production\" of component environment doesn't match the pattern ^[a-z0-9]{2,4}$",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			arguments := map[string]map[string]string{"resource_type": {"fullname": "azurerm_resource_group"}}
+			for key, value := range testCase.arguments {
+				arguments[key] = value
+			}
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+			if testCase.details == nil {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				if result != testCase.name {
+					t.Errorf("expected name %q, got %q", testCase.name, result)
+				}
+				return
+			}
+			var details []string
+			for _, err := range diags.Errors() {
+				if err.Summary() != "Invalid Component Value" {
+					t.Errorf("expected Invalid Component Value error, got %s: %s", err.Summary(), err.Detail())
+				}
+				details = append(details, err.Detail())
+			}
+			if strings.Join(details, "\n") != strings.Join(testCase.details, "\n") {
+				t.Errorf("expected errors %q, got %q", testCase.details, details)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// componentDefinition describes a built-in naming component. The provider schema, the function parameters, the
+// persisted provider configuration and the placeholder resolution are all derived from componentRegistry, so adding a
+// component only requires an entry in the registry. Its default value is configured in the component_defaults provider
+// attribute. The components that existed before component_defaults also keep their default_<name> provider attribute,
+// which needs a field of the provider model tagged with the attribute name because the framework decodes the provider
+// configuration into a struct with a field for every schema attribute.
+type componentDefinition struct {
+	// Name of the component, used as function parameter, as placeholder and as key of the component_defaults provider
+	// attribute
+	Name string
+	// Aliases are alternative placeholder names, aliases of up to 2 characters use the char and aliases of
+	// 3 characters use the shortcode of the component
+	Aliases []string
+	// ValueType is the representation used by placeholders without a format: "full", "short" or "char"
+	ValueType string
+	// Description of the default value of the component
+	Description string
+	// Fallback returns the component whose value is used when the component has no value, or an empty string
+	Fallback func(config resourcenamingtoolProviderModel) string
+}
+
+// componentRegistry lists the built-in components in the order they are documented
+var componentRegistry = []componentDefinition{
+	// Core components
+	{
+		Name:        "resource_type",
+		ValueType:   "full",
+		Description: "Default resource type to use when not provided in the function call. This corresponds to the type of resource being created (e.g., 'virtual_machine', 'storage_account'). The resource type determines which naming pattern is used.",
+	},
+	{
+		Name:        "resource_prefix",
+		ValueType:   "full",
+		Description: "Default resource prefix to use when not provided in the function call. This is an optional prefix that goes before the resource type abbreviation in the name pattern (e.g., 'shared', 'core').",
+	},
+	{
+		Name:        "basename",
+		ValueType:   "full",
+		Description: "Default basename to use when not provided in the function call. This is the core identifying name of the resource that will be part of all resource names (e.g., 'webapp', 'payroll').",
+	},
+	{
+		Name:        "environment",
+		Aliases:     []string{"env", "e"},
+		ValueType:   "full",
+		Description: "Default environment to use when not provided in the function call. Represents the deployment environment (e.g., 'dev', 'test', 'prod'). Used to distinguish resources across different environments.",
+	},
+	{
+		Name:        "region",
+		Aliases:     []string{"r"},
+		ValueType:   "full",
+		Description: "Default region to use when not provided in the function call. Represents the cloud region where the resource is deployed (e.g., 'eastus', 'westeurope', 'us-west-2'). Often used in naming patterns to distinguish resources across regions.",
+	},
+	{
+		Name:        "instance",
+		Aliases:     []string{"inst", "i"},
+		ValueType:   "full",
+		Description: "Default instance identifier to use when not provided in the function call. Used to distinguish between multiple instances of the same resource type (e.g., '01', '02'). Commonly used for resources that are deployed in multiples.",
+	},
+
+	// Organization components
+	{
+		Name:        "organization",
+		Aliases:     []string{"org", "o"},
+		ValueType:   "full",
+		Description: "Default organization to use when not provided in the function call. Identifies the overall organization owning the resource (e.g., 'contoso', 'fabrikam').",
+	},
+	{
+		Name:        "tenant",
+		Aliases:     []string{"ten", "t"},
+		ValueType:   "full",
+		Description: "Default tenant to use when not provided in the function call. Identifies the customer or tenant a resource is deployed for in multi-tenant platforms (e.g., 'fabrikam', 'tenant-042').",
+	},
+	{
+		Name:        "business_unit",
+		Aliases:     []string{"bu"},
+		ValueType:   "full",
+		Description: "Default business unit to use when not provided in the function call. Identifies the business unit within the organization (e.g., 'finance', 'hr', 'it').",
+	},
+	{
+		Name:        "cost_center",
+		Aliases:     []string{"cc"},
+		ValueType:   "full",
+		Description: "Default cost center to use when not provided in the function call. Identifies the financial cost center associated with the resource (e.g., 'cc123', 'marketing').",
+	},
+	{
+		Name:        "project",
+		Aliases:     []string{"proj", "p"},
+		ValueType:   "full",
+		Description: "Default project to use when not provided in the function call. Identifies the project associated with the resource (e.g., 'website-redesign', 'data-migration').",
+	},
+	{
+		Name:        "application",
+		Aliases:     []string{"app", "a"},
+		ValueType:   "full",
+		Description: "Default application name to use when not provided in the function call. Identifies the application using the resource (e.g., 'inventory-system', 'crm').",
+	},
+	{
+		Name:        "workload",
+		Aliases:     []string{"wl", "w"},
+		ValueType:   "full",
+		Description: "Default workload type to use when not provided in the function call. Describes the function or purpose of the workload (e.g., 'api', 'web', 'batch').",
+	},
+
+	// Provider-specific components
+	{
+		Name:        "subscription",
+		Aliases:     []string{"sub", "s"},
+		ValueType:   "full",
+		Description: "Default subscription to use when not provided in the function call. Primarily used with Azure to identify the subscription context (e.g., 'prod', 'dev', 'subscription-name').",
+	},
+	{
+		Name:        "location",
+		Aliases:     []string{"loc", "l"},
+		ValueType:   "full",
+		Description: "Default location to use when not provided in the function call. Used by the {location} placeholder, for cloud providers that distinguish a location from a region (e.g., 'eastus', 'westeurope'). Falls back to the region unless location_fallback_to_region is false.",
+		Fallback: func(config resourcenamingtoolProviderModel) string {
+			if locationFallsBackToRegion(config) {
+				return "region"
+			}
+			return ""
+		},
+	},
+	{
+		Name:        "domain",
+		Aliases:     []string{"d"},
+		ValueType:   "full",
+		Description: "Default domain to use when not provided in the function call. Used for resources that require a domain name (e.g., 'contoso.com', 'fabrikam.net').",
+	},
+	{
+		Name:        "criticality",
+		Aliases:     []string{"crit", "c"},
+		ValueType:   "full",
+		Description: "Default criticality level to use when not provided in the function call. Indicates the importance or criticality of the resource (e.g., 'high', 'medium', 'low', 'mission-critical').",
+	},
+	{
+		Name:        "data_classification",
+		Aliases:     []string{"dc"},
+		ValueType:   "full",
+		Description: "Default data classification to use when not provided in the function call. Indicates the sensitivity of the data stored or processed by the resource (e.g., 'public', 'internal', 'confidential').",
+	},
+
+	// Initiative/solution components
+	{
+		Name:        "initiative",
+		Aliases:     []string{"init"},
+		ValueType:   "full",
+		Description: "Default initiative to use when not provided in the function call. Identifies a broader business initiative the resource belongs to (e.g., 'cloud-migration', 'security-enhancement').",
+	},
+	{
+		Name:        "solution",
+		Aliases:     []string{"sol"},
+		ValueType:   "full",
+		Description: "Default solution to use when not provided in the function call. Identifies the solution architecture or pattern the resource is part of (e.g., 'microservices', 'data-lake').",
+	},
+}
+
+// componentDefaultsAttribute is the provider attribute holding the default values of the components keyed by name
+const componentDefaultsAttribute = "component_defaults"
+
+// ProviderAttribute returns the name of the default_<name> provider attribute of the component, e.g.
+// default_cost_center, which only exists for the components with a field in the provider model, see
+// HasProviderAttribute
+func (d componentDefinition) ProviderAttribute() string {
+	return "default_" + d.Name
+}
+
+// componentDefaultFields holds the index of the ComponentValueObject fields of the provider model keyed by their
+// tfsdk tag, e.g. default_basename
+var componentDefaultFields = func() map[string]int {
+	fields := make(map[string]int)
+	modelType := reflect.TypeOf(resourcenamingtoolProviderModel{})
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if field.Type == reflect.TypeOf(ComponentValueObject{}) {
+			fields[field.Tag.Get("tfsdk")] = i
+		}
+	}
+	return fields
+}()
+
+// HasProviderAttribute reports whether the component has a default_<name> provider attribute
+func (d componentDefinition) HasProviderAttribute() bool {
+	_, ok := componentDefaultFields[d.ProviderAttribute()]
+	return ok
+}
+
+// Default returns the default value of the component, or a null value when it has none. The entry of the component
+// in component_defaults takes precedence over its default_<name> attribute, ValidateConfig rejects setting both.
+func (d componentDefinition) Default(config resourcenamingtoolProviderModel) ComponentValueObject {
+	if value, ok := componentDefaultsEntry(config, d.Name); ok {
+		return value
+	}
+	return d.providerAttributeValue(config)
+}
+
+// DefaultPath returns the path of the provider attribute holding the default value of the component, for diagnostics
+func (d componentDefinition) DefaultPath(config resourcenamingtoolProviderModel) path.Path {
+	if _, ok := componentDefaultsEntry(config, d.Name); ok || !d.HasProviderAttribute() {
+		return path.Root(componentDefaultsAttribute).AtMapKey(d.Name)
+	}
+	return path.Root(d.ProviderAttribute())
+}
+
+// providerAttributeValue returns the value of the default_<name> attribute, or a null value when the component
+// doesn't have the attribute
+func (d componentDefinition) providerAttributeValue(config resourcenamingtoolProviderModel) ComponentValueObject {
+	index, ok := componentDefaultFields[d.ProviderAttribute()]
+	if !ok {
+		return NewComponentValueObjectNull()
+	}
+	return reflect.ValueOf(config).Field(index).Interface().(ComponentValueObject)
+}
+
+// componentDefaultsEntry returns the value of a component in the component_defaults provider attribute
+func componentDefaultsEntry(config resourcenamingtoolProviderModel, name string) (ComponentValueObject, bool) {
+	if config.ComponentDefaults.IsNull() || config.ComponentDefaults.IsUnknown() {
+		return ComponentValueObject{}, false
+	}
+	var component ComponentValueObject
+	switch value := config.ComponentDefaults.Elements()[name].(type) {
+	case ComponentValueObject:
+		component = value
+	case basetypes.ObjectValue:
+		component = ComponentValueObject{ObjectValue: value}
+	default:
+		return ComponentValueObject{}, false
+	}
+	return component, !component.IsNull()
+}
+
+// componentDefaultsToMap converts default values keyed by component name into the component_defaults provider attribute
+func componentDefaultsToMap(defaults map[string]ComponentValueObject) (types.Map, diag.Diagnostics) {
+	values := make(map[string]attr.Value, len(defaults))
+	for name, value := range defaults {
+		values[name] = value
+	}
+	return types.MapValue(NewComponentValueType(), values)
+}
+
+// ConfigKey returns the key of the default value in the persisted provider configuration, e.g. DefaultCostCenter
+func (d componentDefinition) ConfigKey() string {
+	var key strings.Builder
+	key.WriteString("Default")
+	for _, part := range strings.Split(d.Name, "_") {
+		if part == "" {
+			continue
+		}
+		key.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return key.String()
+}
+
+// lookupComponentDefinition returns the built-in component with the given name
+func lookupComponentDefinition(name string) (componentDefinition, bool) {
+	for _, definition := range componentRegistry {
+		if definition.Name == name {
+			return definition, true
+		}
+	}
+	return componentDefinition{}, false
+}
+
+// lookupComponentPlaceholder maps a placeholder name or alias to its built-in component and the
+// value type used when the placeholder doesn't specify one explicitly
+func lookupComponentPlaceholder(name string) (string, string, bool) {
+	for _, definition := range componentRegistry {
+		if definition.Name == name {
+			return definition.Name, definition.ValueType, true
+		}
+		for _, alias := range definition.Aliases {
+			if alias != name {
+				continue
+			}
+			// For abbreviated aliases, use the appropriate format
+			switch {
+			case len(alias) <= 2: // Like {e}, {r}, {bu}, etc.
+				return definition.Name, "char", true
+			case len(alias) <= 3: // Like {env}, {org}, {loc}, etc.
+				return definition.Name, "short", true
+			default:
+				return definition.Name, definition.ValueType, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// componentSchemaAttributes returns the component_defaults provider attribute and the default_<name> provider
+// attributes of the components that have one
+func componentSchemaAttributes() map[string]schema.Attribute {
+	names := make([]string, 0, len(componentRegistry))
+	attributes := make(map[string]schema.Attribute, len(componentRegistry)+1)
+	for _, definition := range componentRegistry {
+		names = append(names, definition.Name)
+		if !definition.HasProviderAttribute() {
+			continue
+		}
+		attributes[definition.ProviderAttribute()] = schema.ObjectAttribute{
+			CustomType:  NewComponentValueType(),
+			Optional:    true,
+			Description: definition.Description,
+		}
+	}
+	attributes[componentDefaultsAttribute] = schema.MapAttribute{
+		ElementType: NewComponentValueType(),
+		Optional:    true,
+		Description: "Default values of the built-in components keyed by component name (e.g., \"tenant\": { fullname = \"fabrikam\" }), used when the function call doesn't provide the component. Supports every built-in component: " + strings.Join(names, ", ") + ". A component cannot have a value in both component_defaults and its default_<name> attribute.",
+	}
+	return attributes
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestComponentRegistry(t *testing.T) {
+	placeholders := make(map[string]string)
+	for _, definition := range componentRegistry {
+		for _, name := range append([]string{definition.Name}, definition.Aliases...) {
+			if other, ok := placeholders[name]; ok {
+				t.Errorf("placeholder {%s} of component %s is already used by component %s", name, definition.Name, other)
+			}
+			placeholders[name] = definition.Name
+		}
+		if _, ok := NewResourceNamingParametersType().AttrTypes[definition.Name]; !ok {
+			t.Errorf("component %s is not a function parameter", definition.Name)
+		}
+	}
+
+	for name, expected := range map[string]string{
+		"basename":            "DefaultBasename",
+		"cost_center":         "DefaultCostCenter",
+		"data_classification": "DefaultDataClassification",
+	} {
+		definition, ok := lookupComponentDefinition(name)
+		if !ok {
+			t.Fatalf("component %s is not registered", name)
+		}
+		if key := definition.ConfigKey(); key != expected {
+			t.Errorf("expected config key %q for %s, got %q", expected, name, key)
+		}
+	}
+
+}
+
+func TestComponentDefinitionDefault(t *testing.T) {
+	config := resourcenamingtoolProviderModel{
+		DefaultCostCenter: testComponentValue(t, "marketing", "mkt", "m"),
+		DefaultProject:    testComponentValue(t, "migration", "mig", "m"),
+		ComponentDefaults: testComponentDefaults(t, map[string]ComponentValueObject{
+			"tenant":  testComponentValue(t, "fabrikam", "fab", "f"),
+			"project": testComponentValue(t, "redesign", "rds", "r"),
+		}),
+	}
+	testCases := map[string]struct {
+		definition componentDefinition
+		fullname   string
+		path       string
+	}{
+		"provider attribute":    {componentDefinition{Name: "cost_center"}, "marketing", "default_cost_center"},
+		"component defaults":    {componentDefinition{Name: "tenant"}, "fabrikam", `component_defaults["tenant"]`},
+		"precedence":            {componentDefinition{Name: "project"}, "redesign", `component_defaults["project"]`},
+		"no default":            {componentDefinition{Name: "domain"}, "", "default_domain"},
+		"no provider attribute": {componentDefinition{Name: "cost_unit"}, "", `component_defaults["cost_unit"]`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			value := testCase.definition.Default(config)
+			if fullname, _ := value.GetFullname(context.Background()); fullname != testCase.fullname {
+				t.Errorf("expected default %q, got %q", testCase.fullname, fullname)
+			}
+			if value.IsNull() != (testCase.fullname == "") {
+				t.Errorf("expected a null default only without a value, got %s", value)
+			}
+			if attributePath := testCase.definition.DefaultPath(config).String(); attributePath != testCase.path {
+				t.Errorf("expected path %s, got %s", testCase.path, attributePath)
+			}
+		})
+	}
+}
+
+func TestComponentRegistry_ProviderModel(t *testing.T) {
+	ctx := context.Background()
+	var resp provider.SchemaResponse
+	(&resourcenamingtoolFunctionsProvider{}).Schema(ctx, provider.SchemaRequest{}, &resp)
+
+	// Every attribute of the schema, including the registered default_<name> attributes, must have a field in the model
+	objectType := resp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	config := tfsdk.Config{Schema: resp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	var model resourcenamingtoolProviderModel
+	if diags := config.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("provider schema doesn't match the provider model: %v", diags)
+	}
+
+	if _, ok := resp.Schema.Attributes[componentDefaultsAttribute]; !ok {
+		t.Errorf("the provider has no %s attribute", componentDefaultsAttribute)
+	}
+	for _, definition := range componentRegistry {
+		if _, ok := resp.Schema.Attributes[definition.ProviderAttribute()]; ok != definition.HasProviderAttribute() {
+			t.Errorf("expected component %s to have a %s provider attribute: %t", definition.Name, definition.ProviderAttribute(), definition.HasProviderAttribute())
+		}
+	}
+}
+
+func TestComponentRegistry_MarshalJSON(t *testing.T) {
+	config := resourcenamingtoolProviderModel{
+		DefaultTenant: testComponentValue(t, "fabrikam", "fab", "f"),
+		ComponentDefaults: testComponentDefaults(t, map[string]ComponentValueObject{
+			"data_classification": testComponentValue(t, "confidential", "conf", "c"),
+		}),
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var output map[string]map[string]string
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if output["DefaultTenant"]["shortcode"] != "fab" || output["DefaultDataClassification"]["fullname"] != "confidential" {
+		t.Errorf("unexpected configuration %s", data)
+	}
+}
+
+func TestComponentRegistry_SaveConfig(t *testing.T) {
+	ctx := context.Background()
+	config := &resourcenamingtoolProviderModel{
+		DefaultBasename: testComponentValue(t, "payroll", "pay", "p"),
+		ComponentDefaults: testComponentDefaults(t, map[string]ComponentValueObject{
+			"tenant": testComponentValue(t, "fabrikam", "fab", "f"),
+		}),
+	}
+	if err := saveProviderConfigToFile(ctx, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	loaded := loadProviderConfigFromFile(ctx)
+	if loaded == nil {
+		t.Fatal("expected the saved configuration to be loaded")
+	}
+	for name, expected := range map[string]string{"basename": "payroll", "tenant": "fabrikam"} {
+		definition, _ := lookupComponentDefinition(name)
+		if fullname, _ := definition.Default(*loaded).GetFullname(ctx); fullname != expected {
+			t.Errorf("expected the default of %s to be %q, got %q", name, expected, fullname)
+		}
+	}
+}
+
+// testComponentDefaults returns the component_defaults provider attribute for the default values
+func testComponentDefaults(t *testing.T, defaults map[string]ComponentValueObject) types.Map {
+	t.Helper()
+	componentDefaults, diags := componentDefaultsToMap(defaults)
+	if diags.HasError() {
+		t.Fatalf("failed to create component defaults: %v", diags)
+	}
+	return componentDefaults
+}
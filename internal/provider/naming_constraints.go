@@ -0,0 +1,547 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// namingConstraint describes the naming rules a cloud provider enforces for a resource type.
+// Character classes are written as the body of a regular expression bracket expression, e.g. "a-z0-9-".
+type namingConstraint struct {
+	MinLength          int    `json:"min_length,omitempty"`
+	MaxLength          int    `json:"max_length,omitempty"`
+	AllowedCharacters  string `json:"allowed_characters,omitempty"`
+	Case               string `json:"case,omitempty"`
+	LeadingCharacters  string `json:"leading_characters,omitempty"`
+	TrailingCharacters string `json:"trailing_characters,omitempty"`
+	// Sanitize lists the filters applied to every component value, e.g. "lower" or "remove:-", to strip or
+	// replace characters the resource type doesn't accept. An empty list disables the built-in sanitization.
+	Sanitize []string `json:"sanitize"`
+	// Shortening configures how names exceeding MaxLength are shortened, it overrides the provider wide strategy
+	Shortening *shorteningStrategy `json:"shortening,omitempty"`
+	// Separator is the value of the {sep} placeholder for the resource type, nil inherits the provider default
+	// and an empty string joins the components without a separator
+	Separator *string `json:"separator,omitempty"`
+	// LengthUnit is how min_length and max_length are counted, see defaultLengthUnit
+	LengthUnit string `json:"length_unit,omitempty"`
+}
+
+// namingRule is a naming_rules entry of the provider configuration. Rules that are nil aren't set and are inherited
+// from the built-in rules, so an explicit zero or empty value, e.g. min_length = 0 or leading_characters = "",
+// relaxes the built-in rule of the resource type.
+type namingRule struct {
+	MinLength          *int                `json:"min_length,omitempty"`
+	MaxLength          *int                `json:"max_length,omitempty"`
+	AllowedCharacters  *string             `json:"allowed_characters,omitempty"`
+	Case               *string             `json:"case,omitempty"`
+	LeadingCharacters  *string             `json:"leading_characters,omitempty"`
+	TrailingCharacters *string             `json:"trailing_characters,omitempty"`
+	Sanitize           []string            `json:"sanitize"`
+	Shortening         *shorteningStrategy `json:"shortening,omitempty"`
+	Separator          *string             `json:"separator,omitempty"`
+	LengthUnit         *string             `json:"length_unit,omitempty"`
+}
+
+// namingRuleModel is the Terraform representation of a naming_rules entry
+type namingRuleModel struct {
+	MinLength          types.Int64  `tfsdk:"min_length"`
+	MaxLength          types.Int64  `tfsdk:"max_length"`
+	AllowedCharacters  types.String `tfsdk:"allowed_characters"`
+	Case               types.String `tfsdk:"case"`
+	LeadingCharacters  types.String `tfsdk:"leading_characters"`
+	TrailingCharacters types.String `tfsdk:"trailing_characters"`
+	Sanitize           types.List   `tfsdk:"sanitize"`
+	Shortening         types.Object `tfsdk:"shortening"`
+	Separator          types.String `tfsdk:"separator"`
+	LengthUnit         types.String `tfsdk:"length_unit"`
+}
+
+// namingRuleAttributeTypes are the attribute types of a naming_rules entry
+var namingRuleAttributeTypes = map[string]attr.Type{
+	"min_length":          types.Int64Type,
+	"max_length":          types.Int64Type,
+	"allowed_characters":  types.StringType,
+	"case":                types.StringType,
+	"leading_characters":  types.StringType,
+	"trailing_characters": types.StringType,
+	"sanitize":            types.ListType{ElemType: types.StringType},
+	"shortening":          types.ObjectType{AttrTypes: shorteningAttributeTypes},
+	"separator":           types.StringType,
+	"length_unit":         types.StringType,
+}
+
+// Supported values for the case rule
+const (
+	namingCaseAny   = "any"
+	namingCaseLower = "lower"
+	namingCaseUpper = "upper"
+)
+
+var (
+	// defaultNamingConstraint applies to resource types without built-in or configured naming rules
+	defaultNamingConstraint = namingConstraint{
+		MinLength: 3,
+		MaxLength: 90,
+	}
+
+	// Sanitization filters shared by the builtin naming constraints
+	sanitizeLower             = []string{"lower"}
+	sanitizeLowerHyphens      = []string{"lower", "replace:_:-", "replace:.:-"}
+	sanitizeAlphanumeric      = []string{"remove:-_."}
+	sanitizeLowerAlphanumeric = []string{"lower", "remove:-_."}
+
+	// Define builtin naming constraints following the naming rules and restrictions documented by the cloud providers:
+	// - Azure: https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules
+	// - AWS: service specific quotas and naming rules
+	// - GCP: https://cloud.google.com/compute/docs/naming-resources
+	builtin_NamingConstraints = map[string]namingConstraint{
+		// Azure Core Resources
+		"azurerm_resource_group":         {MinLength: 1, MaxLength: 90, AllowedCharacters: `a-zA-Z0-9._()-`, TrailingCharacters: `a-zA-Z0-9_()-`},
+		"azurerm_virtual_network":        {MinLength: 2, MaxLength: 64, AllowedCharacters: `a-zA-Z0-9._-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9_`},
+		"azurerm_subnet":                 {MinLength: 1, MaxLength: 80, AllowedCharacters: `a-zA-Z0-9._-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9_`},
+		"azurerm_network_security_group": {MinLength: 1, MaxLength: 80, AllowedCharacters: `a-zA-Z0-9._-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9_`},
+		"azurerm_route_table":            {MinLength: 1, MaxLength: 80, AllowedCharacters: `a-zA-Z0-9._-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9_`},
+		"azurerm_public_ip":              {MinLength: 1, MaxLength: 80, AllowedCharacters: `a-zA-Z0-9._-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9_`},
+
+		// Azure Compute Resources
+		"azurerm_virtual_machine":         {MinLength: 1, MaxLength: 64, AllowedCharacters: `a-zA-Z0-9._-`, TrailingCharacters: `a-zA-Z0-9_`},
+		"azurerm_linux_virtual_machine":   {MinLength: 1, MaxLength: 64, AllowedCharacters: `a-zA-Z0-9._-`, TrailingCharacters: `a-zA-Z0-9_`},
+		"azurerm_windows_virtual_machine": {MinLength: 1, MaxLength: 15, AllowedCharacters: `a-zA-Z0-9-`, TrailingCharacters: `a-zA-Z0-9`},
+		"azurerm_kubernetes_cluster":      {MinLength: 1, MaxLength: 63, AllowedCharacters: `a-zA-Z0-9_-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9`},
+
+		// Azure Storage Resources
+		"azurerm_storage_account":   {MinLength: 3, MaxLength: 24, AllowedCharacters: `a-z0-9`, Case: namingCaseLower, Sanitize: sanitizeLowerAlphanumeric},
+		"azurerm_storage_container": {MinLength: 3, MaxLength: 63, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z0-9`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+
+		// Azure Database Resources
+		"azurerm_mssql_server":      {MinLength: 1, MaxLength: 63, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z0-9`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+		"azurerm_sql_server":        {MinLength: 1, MaxLength: 63, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z0-9`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+		"azurerm_cosmosdb_account":  {MinLength: 3, MaxLength: 44, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z0-9`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+		"azurerm_postgresql_server": {MinLength: 3, MaxLength: 63, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z0-9`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+		"azurerm_mysql_server":      {MinLength: 3, MaxLength: 63, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z0-9`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+
+		// Azure App Resources
+		"azurerm_app_service":      {MinLength: 2, MaxLength: 60, AllowedCharacters: `a-zA-Z0-9-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9`},
+		"azurerm_linux_web_app":    {MinLength: 2, MaxLength: 60, AllowedCharacters: `a-zA-Z0-9-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9`},
+		"azurerm_windows_web_app":  {MinLength: 2, MaxLength: 60, AllowedCharacters: `a-zA-Z0-9-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9`},
+		"azurerm_function_app":     {MinLength: 2, MaxLength: 60, AllowedCharacters: `a-zA-Z0-9-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9`},
+		"azurerm_app_service_plan": {MinLength: 1, MaxLength: 60, AllowedCharacters: `a-zA-Z0-9-`},
+
+		// Azure Security Resources
+		"azurerm_key_vault": {MinLength: 3, MaxLength: 24, AllowedCharacters: `a-zA-Z0-9-`, LeadingCharacters: `a-zA-Z`, TrailingCharacters: `a-zA-Z0-9`},
+
+		// Azure Integration Resources
+		"azurerm_servicebus_namespace": {MinLength: 6, MaxLength: 50, AllowedCharacters: `a-zA-Z0-9-`, LeadingCharacters: `a-zA-Z`, TrailingCharacters: `a-zA-Z0-9`},
+		"azurerm_eventhub_namespace":   {MinLength: 6, MaxLength: 50, AllowedCharacters: `a-zA-Z0-9-`, LeadingCharacters: `a-zA-Z`, TrailingCharacters: `a-zA-Z0-9`},
+
+		// Azure Container Resources
+		"azurerm_container_registry": {MinLength: 5, MaxLength: 50, AllowedCharacters: `a-zA-Z0-9`, Sanitize: sanitizeAlphanumeric},
+
+		// Azure Analytics Resources
+		"azurerm_log_analytics_workspace": {MinLength: 4, MaxLength: 63, AllowedCharacters: `a-zA-Z0-9-`, LeadingCharacters: `a-zA-Z0-9`, TrailingCharacters: `a-zA-Z0-9`},
+
+		// AWS Resources
+		"aws_s3_bucket":       {MinLength: 3, MaxLength: 63, AllowedCharacters: `a-z0-9.-`, Case: namingCaseLower, LeadingCharacters: `a-z0-9`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+		"aws_iam_role":        {MinLength: 1, MaxLength: 64, AllowedCharacters: `a-zA-Z0-9+=,.@_-`},
+		"aws_iam_policy":      {MinLength: 1, MaxLength: 128, AllowedCharacters: `a-zA-Z0-9+=,.@_-`},
+		"aws_lambda_function": {MinLength: 1, MaxLength: 64, AllowedCharacters: `a-zA-Z0-9_-`},
+		"aws_sqs_queue":       {MinLength: 1, MaxLength: 80, AllowedCharacters: `a-zA-Z0-9_-`},
+		"aws_dynamodb_table":  {MinLength: 3, MaxLength: 255, AllowedCharacters: `a-zA-Z0-9_.-`},
+		"aws_ecr_repository":  {MinLength: 2, MaxLength: 256, AllowedCharacters: `a-z0-9._/-`, Case: namingCaseLower, Sanitize: sanitizeLower},
+		"aws_db_instance":     {MinLength: 1, MaxLength: 63, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+		"aws_eks_cluster":     {MinLength: 1, MaxLength: 100, AllowedCharacters: `a-zA-Z0-9_-`, LeadingCharacters: `a-zA-Z0-9`},
+
+		// GCP Resources
+		"google_storage_bucket":     {MinLength: 3, MaxLength: 63, AllowedCharacters: `a-z0-9_.-`, Case: namingCaseLower, LeadingCharacters: `a-z0-9`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLower},
+		"google_compute_instance":   {MinLength: 1, MaxLength: 63, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+		"google_compute_network":    {MinLength: 1, MaxLength: 63, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+		"google_compute_subnetwork": {MinLength: 1, MaxLength: 63, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+		"google_container_cluster":  {MinLength: 1, MaxLength: 40, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+		"google_service_account":    {MinLength: 6, MaxLength: 30, AllowedCharacters: `a-z0-9-`, Case: namingCaseLower, LeadingCharacters: `a-z`, TrailingCharacters: `a-z0-9`, Sanitize: sanitizeLowerHyphens},
+	}
+)
+
+// namingConstraintViolation describes a naming rule that a generated name doesn't satisfy
+type namingConstraintViolation struct {
+	Rule    string
+	Summary string
+	Detail  string
+}
+
+// merge returns the constraint with every rule that is set in override replaced
+func (c namingConstraint) merge(override namingRule) namingConstraint {
+	if override.MinLength != nil {
+		c.MinLength = *override.MinLength
+	}
+	if override.MaxLength != nil {
+		c.MaxLength = *override.MaxLength
+	}
+	if override.AllowedCharacters != nil {
+		c.AllowedCharacters = *override.AllowedCharacters
+	}
+	if override.Case != nil {
+		c.Case = *override.Case
+	}
+	if override.LeadingCharacters != nil {
+		c.LeadingCharacters = *override.LeadingCharacters
+	}
+	if override.TrailingCharacters != nil {
+		c.TrailingCharacters = *override.TrailingCharacters
+	}
+	if override.Sanitize != nil {
+		c.Sanitize = override.Sanitize
+	}
+	if override.Shortening != nil {
+		c.Shortening = override.Shortening
+	}
+	if override.Separator != nil {
+		c.Separator = override.Separator
+	}
+	if override.LengthUnit != nil {
+		c.LengthUnit = *override.LengthUnit
+	}
+	return c
+}
+
+// constraint returns the rules that are set as a naming constraint, to validate the rule on its own
+func (r namingRule) constraint() namingConstraint {
+	return namingConstraint{}.merge(r)
+}
+
+// validate checks that the rules of the constraint are consistent and can be applied
+func (c namingConstraint) validate() error {
+	if c.MinLength < 0 || c.MaxLength < 0 {
+		return fmt.Errorf("min_length and max_length cannot be negative")
+	}
+	if c.MinLength != 0 && c.MaxLength != 0 && c.MinLength > c.MaxLength {
+		return fmt.Errorf("min_length %d is greater than max_length %d", c.MinLength, c.MaxLength)
+	}
+	switch c.Case {
+	case "", namingCaseAny, namingCaseLower, namingCaseUpper:
+	default:
+		return fmt.Errorf("case %q is not supported, expected %s, %s or %s", c.Case, namingCaseAny, namingCaseLower, namingCaseUpper)
+	}
+	switch c.LengthUnit {
+	case "", namingLengthCharacters, namingLengthBytes, namingLengthUTF16:
+	default:
+		return fmt.Errorf("length_unit %q is not supported, expected %s, %s or %s", c.LengthUnit, namingLengthCharacters, namingLengthBytes, namingLengthUTF16)
+	}
+	for rule, class := range map[string]string{
+		"allowed_characters":  c.AllowedCharacters,
+		"leading_characters":  c.LeadingCharacters,
+		"trailing_characters": c.TrailingCharacters,
+	} {
+		if class == "" {
+			continue
+		}
+		if _, err := compileCharacterClass(class); err != nil {
+			return fmt.Errorf("%s %q is not a valid character class: %s", rule, class, err.Error())
+		}
+	}
+	if _, err := c.sanitizeFilters(); err != nil {
+		return err
+	}
+	if c.Separator != nil {
+		if metacharacter := findPatternMetacharacter(*c.Separator); metacharacter != "" {
+			return fmt.Errorf("separator %q contains %q, which is reserved for naming patterns", *c.Separator, metacharacter)
+		}
+	}
+	if c.Shortening != nil {
+		if err := c.Shortening.validate(); err != nil {
+			return fmt.Errorf("invalid shortening strategy: %s", err.Error())
+		}
+		if c.Shortening.truncates() && c.MaxLength != 0 && c.MaxLength <= c.Shortening.hashLength() {
+			return fmt.Errorf("max_length %d leaves no room for the hash of %d characters appended by the truncate step", c.MaxLength, c.Shortening.hashLength())
+		}
+	}
+	return nil
+}
+
+// sanitizeFilters parses the sanitize filters of the constraint
+func (c namingConstraint) sanitizeFilters() ([]patternFilter, error) {
+	filters := make([]patternFilter, 0, len(c.Sanitize))
+	for _, spec := range c.Sanitize {
+		filter, err := parsePatternFilter(spec, spec, 1)
+		if err != nil {
+			var syntaxErr *patternSyntaxError
+			if errors.As(err, &syntaxErr) {
+				return nil, fmt.Errorf("sanitize filter %q is invalid: %s", spec, syntaxErr.Message)
+			}
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// check verifies name against the constraint and returns every rule that is violated
+func (c namingConstraint) check(name, resourceType string) []namingConstraintViolation {
+	var violations []namingConstraintViolation
+	length := c.length(name)
+	// Only mention the length unit when it makes a difference, e.g. for names with multi-byte characters
+	unit := lengthUnitDescription(namingLengthCharacters)
+	if length != utf8.RuneCountInString(name) {
+		unit = lengthUnitDescription(c.LengthUnit)
+	}
+
+	if c.MaxLength > 0 && length > c.MaxLength {
+		violations = append(violations, namingConstraintViolation{
+			Rule:    "max_length",
+			Summary: "Name Too Long",
+			Detail:  fmt.Sprintf("Resource name %q is %d %s long, exceeding the max_length of %d for resource type %s", name, length, unit, c.MaxLength, resourceType),
+		})
+	}
+	if c.MinLength > 0 && length < c.MinLength {
+		violations = append(violations, namingConstraintViolation{
+			Rule:    "min_length",
+			Summary: "Name Too Short",
+			Detail:  fmt.Sprintf("Resource name %q is %d %s long, below the min_length of %d for resource type %s", name, length, unit, c.MinLength, resourceType),
+		})
+	}
+	if c.AllowedCharacters != "" {
+		if invalid := invalidCharacters(name, c.AllowedCharacters); invalid != "" {
+			violations = append(violations, namingConstraintViolation{
+				Rule:    "allowed_characters",
+				Summary: "Invalid Characters",
+				Detail:  fmt.Sprintf("Resource name %q contains %q, which is not allowed by the allowed_characters rule [%s] for resource type %s", name, invalid, c.AllowedCharacters, resourceType),
+			})
+		}
+	}
+	switch c.Case {
+	case namingCaseLower:
+		if name != strings.ToLower(name) {
+			violations = append(violations, namingConstraintViolation{
+				Rule:    "case",
+				Summary: "Invalid Case",
+				Detail:  fmt.Sprintf("Resource name %q must be lower case according to the case rule for resource type %s", name, resourceType),
+			})
+		}
+	case namingCaseUpper:
+		if name != strings.ToUpper(name) {
+			violations = append(violations, namingConstraintViolation{
+				Rule:    "case",
+				Summary: "Invalid Case",
+				Detail:  fmt.Sprintf("Resource name %q must be upper case according to the case rule for resource type %s", name, resourceType),
+			})
+		}
+	}
+	if c.LeadingCharacters != "" && name != "" {
+		first, _ := utf8.DecodeRuneInString(name)
+		if invalidCharacters(string(first), c.LeadingCharacters) != "" {
+			violations = append(violations, namingConstraintViolation{
+				Rule:    "leading_characters",
+				Summary: "Invalid Leading Character",
+				Detail:  fmt.Sprintf("Resource name %q starts with %q, which is not allowed by the leading_characters rule [%s] for resource type %s", name, string(first), c.LeadingCharacters, resourceType),
+			})
+		}
+	}
+	if c.TrailingCharacters != "" && name != "" {
+		last, _ := utf8.DecodeLastRuneInString(name)
+		if invalidCharacters(string(last), c.TrailingCharacters) != "" {
+			violations = append(violations, namingConstraintViolation{
+				Rule:    "trailing_characters",
+				Summary: "Invalid Trailing Character",
+				Detail:  fmt.Sprintf("Resource name %q ends with %q, which is not allowed by the trailing_characters rule [%s] for resource type %s", name, string(last), c.TrailingCharacters, resourceType),
+			})
+		}
+	}
+
+	return violations
+}
+
+// length returns the length of name counted in the length unit of the constraint
+func (c namingConstraint) length(name string) int {
+	return nameLength(name, c.LengthUnit)
+}
+
+// compileCharacterClass compiles a character class body, e.g. "a-z0-9-", into a regular expression matching a single character
+func compileCharacterClass(class string) (*regexp.Regexp, error) {
+	return regexp.Compile("^[" + class + "]$")
+}
+
+// invalidCharacters returns the distinct characters of value that are not part of the character class
+func invalidCharacters(value, class string) string {
+	re, err := compileCharacterClass(class)
+	if err != nil {
+		return ""
+	}
+	var invalid strings.Builder
+	for _, r := range value {
+		if !re.MatchString(string(r)) && !strings.ContainsRune(invalid.String(), r) {
+			invalid.WriteRune(r)
+		}
+	}
+	return invalid.String()
+}
+
+// lookupNamingConstraint returns the naming constraint for a resource type, combining the built-in
+// constraints with the naming_rules of the provider configuration
+func lookupNamingConstraint(ctx context.Context, config resourcenamingtoolProviderModel, resourceType string) namingConstraint {
+	constraint, ok := builtin_NamingConstraints[resourceType]
+	if !ok {
+		constraint = defaultNamingConstraint
+	}
+	if constraint.LengthUnit == "" {
+		constraint.LengthUnit = defaultLengthUnit(resourceType)
+	}
+
+	rules, diags := namingRulesFromMap(ctx, config.NamingRules)
+	if diags.HasError() {
+		logErrorWithFields(ctx, "Failed to read naming rules from provider config", map[string]interface{}{
+			"error": diags.Errors()[0].Summary(),
+		})
+		return constraint
+	}
+	if key, ok := lookupResourceTypeKey(rules, resourceType); ok {
+		constraint = constraint.merge(rules[key])
+	}
+
+	logDebugWithFields(ctx, "Using naming constraint", map[string]interface{}{
+		"resource_type": resourceType,
+		"constraint":    fmt.Sprintf("%+v", constraint),
+	})
+	return constraint
+}
+
+// checkNamingRuleConflicts checks that the naming rules of key remain consistent when combined with the built-in
+// naming rules, e.g. a max_length below the built-in min_length. Wildcards and the default key are checked against
+// every built-in resource type they apply to.
+func checkNamingRuleConflicts(ctx context.Context, config resourcenamingtoolProviderModel, rules map[string]namingRule, key string) error {
+	resourceTypes := []string{key}
+	var builtinTypes []string
+	for resourceType := range builtin_NamingConstraints {
+		if match, ok := lookupResourceTypeKey(rules, resourceType); ok && match == key && resourceType != key {
+			builtinTypes = append(builtinTypes, resourceType)
+		}
+	}
+	sort.Strings(builtinTypes)
+	for _, resourceType := range append(resourceTypes, builtinTypes...) {
+		if err := lookupNamingConstraint(ctx, config, resourceType).validate(); err != nil {
+			return fmt.Errorf("the combined naming rules of resource type %s are invalid: %s", resourceType, err.Error())
+		}
+	}
+	return nil
+}
+
+// namingRulesFromMap converts the naming_rules provider attribute into naming rules
+func namingRulesFromMap(ctx context.Context, rules types.Map) (map[string]namingRule, diag.Diagnostics) {
+	result := make(map[string]namingRule)
+	if rules.IsNull() || rules.IsUnknown() {
+		return result, nil
+	}
+
+	var models map[string]namingRuleModel
+	diags := rules.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return result, diags
+	}
+
+	for resourceType, model := range models {
+		shortening, shorteningDiags := shorteningFromObject(ctx, model.Shortening)
+		diags.Append(shorteningDiags...)
+		var sanitize []string
+		if !model.Sanitize.IsNull() && !model.Sanitize.IsUnknown() {
+			sanitize = make([]string, 0, len(model.Sanitize.Elements()))
+			diags.Append(model.Sanitize.ElementsAs(ctx, &sanitize, false)...)
+		}
+		result[resourceType] = namingRule{
+			MinLength:          optionalInt(model.MinLength),
+			MaxLength:          optionalInt(model.MaxLength),
+			AllowedCharacters:  optionalString(model.AllowedCharacters),
+			Case:               optionalString(model.Case),
+			LeadingCharacters:  optionalString(model.LeadingCharacters),
+			TrailingCharacters: optionalString(model.TrailingCharacters),
+			Sanitize:           sanitize,
+			Shortening:         shortening,
+			Separator:          optionalString(model.Separator),
+			LengthUnit:         optionalString(model.LengthUnit),
+		}
+	}
+	return result, diags
+}
+
+// optionalInt returns the value of an optional integer attribute, or nil when it isn't set
+func optionalInt(value types.Int64) *int {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	result := int(value.ValueInt64())
+	return &result
+}
+
+// optionalString returns the value of an optional string attribute, or nil when it isn't set
+func optionalString(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueStringPointer()
+}
+
+// optionalIntValue returns the Terraform value of an optional integer attribute, which is null when it isn't set
+func optionalIntValue(value *int) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
+
+// namingRulesToMap converts naming rules into the naming_rules provider attribute
+func namingRulesToMap(ctx context.Context, rules map[string]namingRule) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	models := make(map[string]namingRuleModel, len(rules))
+	for resourceType, rule := range rules {
+		model := namingRuleModel{
+			MinLength:          optionalIntValue(rule.MinLength),
+			MaxLength:          optionalIntValue(rule.MaxLength),
+			AllowedCharacters:  types.StringPointerValue(rule.AllowedCharacters),
+			Case:               types.StringPointerValue(rule.Case),
+			LeadingCharacters:  types.StringPointerValue(rule.LeadingCharacters),
+			TrailingCharacters: types.StringPointerValue(rule.TrailingCharacters),
+			Sanitize:           types.ListNull(types.StringType),
+			Separator:          types.StringPointerValue(rule.Separator),
+			LengthUnit:         types.StringPointerValue(rule.LengthUnit),
+		}
+		if rule.Sanitize != nil {
+			sanitize, sanitizeDiags := types.ListValueFrom(ctx, types.StringType, rule.Sanitize)
+			diags.Append(sanitizeDiags...)
+			model.Sanitize = sanitize
+		}
+		shortening, shorteningDiags := shorteningToObject(ctx, rule.Shortening)
+		diags.Append(shorteningDiags...)
+		model.Shortening = shortening
+		models[resourceType] = model
+	}
+	rulesMap, mapDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: namingRuleAttributeTypes}, models)
+	diags.Append(mapDiags...)
+	return rulesMap, diags
+}
+
+// namingRulesFromJSON converts the JSON representation of the naming rules, as saved in the
+// configuration file, into the naming_rules provider attribute
+func namingRulesFromJSON(ctx context.Context, raw interface{}) (types.Map, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return types.MapNull(types.ObjectType{AttrTypes: namingRuleAttributeTypes}), err
+	}
+	var rules map[string]namingRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return types.MapNull(types.ObjectType{AttrTypes: namingRuleAttributeTypes}), err
+	}
+	rulesMap, diags := namingRulesToMap(ctx, rules)
+	if diags.HasError() {
+		return types.MapNull(types.ObjectType{AttrTypes: namingRuleAttributeTypes}), fmt.Errorf("%s", diags.Errors()[0].Summary())
+	}
+	return rulesMap, nil
+}
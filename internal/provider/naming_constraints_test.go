@@ -0,0 +1,215 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNamingConstraintCheck(t *testing.T) {
+	testCases := map[string]struct {
+		resourceType string
+		name         string
+		rules        []string
+	}{
+		"valid storage account":     {"azurerm_storage_account", "stexampleprdwe001", nil},
+		"storage account too long":  {"azurerm_storage_account", "stexampleproductionwesteu001", []string{"max_length"}},
+		"storage account hyphens":   {"azurerm_storage_account", "st-example-prd", []string{"allowed_characters"}},
+		"storage account uppercase": {"azurerm_storage_account", "stExample", []string{"allowed_characters", "case"}},
+		"key vault leading digit":   {"azurerm_key_vault", "1kv-example", []string{"leading_characters"}},
+		"key vault trailing hyphen": {"azurerm_key_vault", "kv-example-", []string{"trailing_characters"}},
+		"s3 bucket too short":       {"aws_s3_bucket", "ab", []string{"min_length"}},
+		"default constraint":        {"custom_resource", "a very long name with spaces", nil},
+		"default constraint short":  {"custom_resource", "ab", []string{"min_length"}},
+		"length counts characters":  {"azurerm_storage_account", "stüberlängeexampleprdwe", []string{"allowed_characters"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			constraint, ok := builtin_NamingConstraints[testCase.resourceType]
+			if !ok {
+				constraint = defaultNamingConstraint
+			}
+			violations := constraint.check(testCase.name, testCase.resourceType)
+
+			rules := make([]string, 0, len(violations))
+			for _, violation := range violations {
+				rules = append(rules, violation.Rule)
+			}
+			if strings.Join(rules, ",") != strings.Join(testCase.rules, ",") {
+				t.Errorf("expected violated rules %v, got %v", testCase.rules, rules)
+			}
+		})
+	}
+}
+
+func TestNamingConstraintCheck_LengthUnit(t *testing.T) {
+	// 63 characters, but 64 bytes and 63 UTF-16 code units
+	name := "bücket-" + strings.Repeat("a", 56)
+	testCases := map[string]struct {
+		resourceType string
+		maxLength    int
+		detail       string
+	}{
+		"aws counts bytes":        {"aws_lambda_function", 63, "is 64 bytes long"},
+		"azure counts utf16":      {"azurerm_resource_group", 62, "is 63 characters long"},
+		"others count characters": {"custom_resource", 62, "is 63 characters long"},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			constraint := lookupNamingConstraint(context.Background(), resourcenamingtoolProviderModel{}, testCase.resourceType)
+			constraint = namingConstraint{MaxLength: testCase.maxLength, LengthUnit: constraint.LengthUnit}
+			violations := constraint.check(name, testCase.resourceType)
+			if len(violations) != 1 || violations[0].Rule != "max_length" || !strings.Contains(violations[0].Detail, testCase.detail) {
+				t.Errorf("expected a max_length violation containing %q, got %+v", testCase.detail, violations)
+			}
+		})
+	}
+}
+
+func TestNamingConstraintValidate(t *testing.T) {
+	testCases := map[string]struct {
+		constraint namingConstraint
+		message    string
+	}{
+		"valid":             {namingConstraint{MinLength: 3, MaxLength: 24, AllowedCharacters: `a-z0-9`, Case: namingCaseLower}, ""},
+		"negative length":   {namingConstraint{MinLength: -1}, "cannot be negative"},
+		"min above max":     {namingConstraint{MinLength: 30, MaxLength: 24}, "min_length 30 is greater than max_length 24"},
+		"unsupported case":  {namingConstraint{Case: "camel"}, "case \"camel\" is not supported"},
+		"invalid character": {namingConstraint{LeadingCharacters: `z-a`}, "leading_characters \"z-a\" is not a valid character class"},
+		"invalid sanitize":  {namingConstraint{Sanitize: []string{"lower", "strip"}}, "sanitize filter \"strip\" is invalid: unknown filter \"strip\""},
+		"separator syntax":  {namingConstraint{Separator: stringPointer("{")}, "separator \"{\" contains \"{\", which is reserved for naming patterns"},
+		"invalid unit":      {namingConstraint{LengthUnit: "runes"}, "length_unit \"runes\" is not supported"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.constraint.validate()
+			if testCase.message == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), testCase.message) {
+				t.Errorf("expected error containing %q, got %v", testCase.message, err)
+			}
+		})
+	}
+}
+
+func TestNamingConstraintMerge(t *testing.T) {
+	merged := builtin_NamingConstraints["azurerm_storage_account"].merge(namingRule{MaxLength: intPointer(16)})
+	expected := namingConstraint{MinLength: 3, MaxLength: 16, AllowedCharacters: `a-z0-9`, Case: namingCaseLower, Sanitize: sanitizeLowerAlphanumeric}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %+v, got %+v", expected, merged)
+	}
+}
+
+func TestNamingConstraintMerge_DisableSanitize(t *testing.T) {
+	merged := builtin_NamingConstraints["azurerm_storage_account"].merge(namingRule{Sanitize: []string{}})
+	if merged.Sanitize == nil || len(merged.Sanitize) != 0 {
+		t.Errorf("expected an empty sanitize list to disable the built-in sanitization, got %v", merged.Sanitize)
+	}
+}
+
+func TestNamingConstraintMerge_ExplicitZero(t *testing.T) {
+	merged := builtin_NamingConstraints["azurerm_key_vault"].merge(namingRule{MinLength: intPointer(0), LeadingCharacters: stringPointer("")})
+	if merged.MinLength != 0 || merged.LeadingCharacters != "" {
+		t.Errorf("expected an explicit zero and empty rule to relax the built-in rules, got %+v", merged)
+	}
+	if merged.MaxLength != 24 || merged.TrailingCharacters != `a-zA-Z0-9` {
+		t.Errorf("expected the rules that are not set to be inherited, got %+v", merged)
+	}
+	if violations := merged.check("1k", "azurerm_key_vault"); len(violations) != 0 {
+		t.Errorf("expected no violations, got %+v", violations)
+	}
+}
+
+func TestLookupNamingConstraint_ResourceTypeKeys(t *testing.T) {
+	config := resourcenamingtoolProviderModel{NamingRules: testNamingRules(t, map[string]namingRule{
+		"azurerm_key_vault":   {MaxLength: intPointer(20)},
+		"azurerm_*":           {MaxLength: intPointer(30)},
+		"azurerm_storage_*":   {MaxLength: intPointer(16)},
+		defaultPatternKey:     {MinLength: intPointer(5)},
+		"aws_*_function_name": {MinLength: intPointer(2)},
+	})}
+	testCases := map[string]struct {
+		resourceType string
+		minLength    int
+		maxLength    int
+	}{
+		"exact":            {"azurerm_key_vault", 3, 20},
+		"wildcard":         {"azurerm_resource_group", 1, 30},
+		"specific glob":    {"azurerm_storage_account", 3, 16},
+		"default":          {"custom_resource", 5, 90},
+		"default built-in": {"aws_s3_bucket", 5, 63},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			constraint := lookupNamingConstraint(context.Background(), config, testCase.resourceType)
+			if constraint.MinLength != testCase.minLength || constraint.MaxLength != testCase.maxLength {
+				t.Errorf("expected lengths %d to %d, got %d to %d", testCase.minLength, testCase.maxLength, constraint.MinLength, constraint.MaxLength)
+			}
+		})
+	}
+}
+
+func TestNamingRulesFromJSON(t *testing.T) {
+	ctx := context.Background()
+	configured := testNamingRules(t, map[string]namingRule{
+		"azurerm_key_vault": {MinLength: intPointer(0), MaxLength: intPointer(20), LeadingCharacters: stringPointer("")},
+	})
+	data, err := json.Marshal(resourcenamingtoolProviderModel{NamingRules: configured})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var output map[string]interface{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	loaded, err := namingRulesFromJSON(ctx, output["NamingRules"])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !loaded.Equal(configured) {
+		t.Errorf("expected %s, got %s", configured, loaded)
+	}
+}
+
+func TestCheckNamingRuleConflicts(t *testing.T) {
+	testCases := map[string]struct {
+		rules   map[string]namingRule
+		key     string
+		message string
+	}{
+		"valid":           {map[string]namingRule{"azurerm_key_vault": {MaxLength: intPointer(20)}}, "azurerm_key_vault", ""},
+		"exact":           {map[string]namingRule{"azurerm_key_vault": {MaxLength: intPointer(2)}}, "azurerm_key_vault", "the combined naming rules of resource type azurerm_key_vault are invalid: min_length 3 is greater than max_length 2"},
+		"wildcard":        {map[string]namingRule{"azurerm_*": {MaxLength: intPointer(4)}}, "azurerm_*", "the combined naming rules of resource type azurerm_container_registry are invalid: min_length 5 is greater than max_length 4"},
+		"relaxed":         {map[string]namingRule{"azurerm_*": {MinLength: intPointer(0), MaxLength: intPointer(4)}}, "azurerm_*", ""},
+		"exact overrides": {map[string]namingRule{"azurerm_*": {MaxLength: intPointer(4)}, "azurerm_container_registry": {MinLength: intPointer(1)}, "azurerm_servicebus_namespace": {MinLength: intPointer(1)}, "azurerm_eventhub_namespace": {MinLength: intPointer(1)}}, "azurerm_*", ""},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := resourcenamingtoolProviderModel{NamingRules: testNamingRules(t, testCase.rules)}
+			err := checkNamingRuleConflicts(context.Background(), config, testCase.rules, testCase.key)
+			if testCase.message == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != testCase.message {
+				t.Errorf("expected error %q, got %v", testCase.message, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// patternFilter is a transform filter applied to a placeholder value, e.g. "trunc:8" in {basename|trunc:8}
+type patternFilter struct {
+	Name string
+	Args []string
+	// Column is the 1-based character position of the filter name in the pattern
+	Column int
+}
+
+// namingFilter describes a filter that can be used in placeholders
+type namingFilter struct {
+	MinArgs int
+	MaxArgs int
+	// Validate checks the filter arguments when the pattern is parsed
+	Validate func(args []string) error
+	// Apply transforms a resolved placeholder value
+	Apply func(value string, args []string) string
+}
+
+// namingFilters contains all filters supported in placeholders
+var namingFilters = map[string]namingFilter{
+	// upper converts the value to upper case, e.g. {project:short|upper}
+	"upper": {
+		Apply: func(value string, _ []string) string {
+			return strings.ToUpper(value)
+		},
+	},
+	// lower converts the value to lower case, e.g. {basename|lower}
+	"lower": {
+		Apply: func(value string, _ []string) string {
+			return strings.ToLower(value)
+		},
+	},
+	// ascii transliterates accented Latin letters to ASCII, e.g. {basename|ascii} turns "Zürich" into "Zurich"
+	"ascii": {
+		Apply: func(value string, _ []string) string {
+			return transliterateASCII(value)
+		},
+	},
+	// trunc keeps at most N characters of the value, e.g. {basename|trunc:8}
+	"trunc": {
+		MinArgs: 1,
+		MaxArgs: 1,
+		Validate: func(args []string) error {
+			_, err := parseFilterLength(args[0])
+			return err
+		},
+		Apply: func(value string, args []string) string {
+			length, _ := parseFilterLength(args[0])
+			runes := []rune(value)
+			if len(runes) > length {
+				return string(runes[:length])
+			}
+			return value
+		},
+	},
+	// pad left pads the value to N characters, using "0" unless another character is given, e.g. {instance|pad:3:0}
+	"pad": {
+		MinArgs: 1,
+		MaxArgs: 2,
+		Validate: func(args []string) error {
+			if _, err := parseFilterLength(args[0]); err != nil {
+				return err
+			}
+			if len(args) > 1 && len([]rune(args[1])) != 1 {
+				return fmt.Errorf("padding character %q must be a single character", args[1])
+			}
+			return nil
+		},
+		Apply: func(value string, args []string) string {
+			length, _ := parseFilterLength(args[0])
+			padding := "0"
+			if len(args) > 1 {
+				padding = args[1]
+			}
+			if missing := length - len([]rune(value)); missing > 0 {
+				return strings.Repeat(padding, missing) + value
+			}
+			return value
+		},
+	},
+	// remove removes all occurrences of each of the given characters, e.g. {basename|remove:-_.}
+	"remove": {
+		MinArgs: 1,
+		MaxArgs: 1,
+		Validate: func(args []string) error {
+			if args[0] == "" {
+				return fmt.Errorf("the characters to remove cannot be empty")
+			}
+			return nil
+		},
+		Apply: func(value string, args []string) string {
+			return strings.Map(func(r rune) rune {
+				if strings.ContainsRune(args[0], r) {
+					return -1
+				}
+				return r
+			}, value)
+		},
+	},
+	// replace replaces all occurrences of a string with another (possibly empty) string, e.g. {application|replace:-:}
+	"replace": {
+		MinArgs: 2,
+		MaxArgs: 2,
+		Validate: func(args []string) error {
+			if args[0] == "" {
+				return fmt.Errorf("the string to replace cannot be empty")
+			}
+			return nil
+		},
+		Apply: func(value string, args []string) string {
+			return strings.ReplaceAll(value, args[0], args[1])
+		},
+	},
+}
+
+// parseFilterLength parses a positive length argument of a filter
+func parseFilterLength(arg string) (int, error) {
+	length, err := strconv.Atoi(arg)
+	if err != nil || length < 1 {
+		return 0, fmt.Errorf("length %q must be a positive number", arg)
+	}
+	return length, nil
+}
+
+// parsePatternFilter parses a single filter section of a placeholder, e.g. "pad:3:0"
+func parsePatternFilter(pattern, section string, column int) (patternFilter, error) {
+	parts := strings.Split(section, ":")
+	filter := patternFilter{Name: parts[0], Args: parts[1:], Column: column}
+
+	if filter.Name == "" {
+		return patternFilter{}, &patternSyntaxError{Pattern: pattern, Column: column, Message: "empty filter"}
+	}
+
+	definition, ok := namingFilters[filter.Name]
+	if !ok {
+		return patternFilter{}, &patternSyntaxError{
+			Pattern: pattern,
+			Column:  column,
+			Message: fmt.Sprintf("unknown filter %q, expected one of %s", filter.Name, strings.Join(namingFilterNames(), ", ")),
+		}
+	}
+	if len(filter.Args) < definition.MinArgs || len(filter.Args) > definition.MaxArgs {
+		return patternFilter{}, &patternSyntaxError{
+			Pattern: pattern,
+			Column:  column,
+			Message: fmt.Sprintf("filter %q expects %s, got %d", filter.Name, describeFilterArgs(definition), len(filter.Args)),
+		}
+	}
+	if definition.Validate != nil {
+		if err := definition.Validate(filter.Args); err != nil {
+			return patternFilter{}, &patternSyntaxError{
+				Pattern: pattern,
+				Column:  column,
+				Message: fmt.Sprintf("invalid arguments for filter %q: %s", filter.Name, err.Error()),
+			}
+		}
+	}
+
+	return filter, nil
+}
+
+// describeFilterArgs describes the number of arguments a filter accepts
+func describeFilterArgs(definition namingFilter) string {
+	switch {
+	case definition.MaxArgs == 0:
+		return "no arguments"
+	case definition.MinArgs == definition.MaxArgs:
+		return fmt.Sprintf("%d argument(s)", definition.MinArgs)
+	default:
+		return fmt.Sprintf("%d to %d arguments", definition.MinArgs, definition.MaxArgs)
+	}
+}
+
+// namingFilterNames returns the sorted names of all supported filters
+func namingFilterNames() []string {
+	names := make([]string, 0, len(namingFilters))
+	for name := range namingFilters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyPatternFilters applies the filters of a placeholder to its resolved value, from left to right
+func applyPatternFilters(value string, filters []patternFilter) string {
+	for _, filter := range filters {
+		value = namingFilters[filter.Name].Apply(value, filter.Args)
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestApplyPatternFilters(t *testing.T) {
+	testCases := map[string]struct {
+		pattern  string
+		value    string
+		expected string
+	}{
+		"upper":          {"{project:short|upper}", "web", "WEB"},
+		"lower":          {"{basename|lower}", "WebApp", "webapp"},
+		"trunc":          {"{basename|trunc:8}", "inventorysystem", "inventor"},
+		"trunc short":    {"{basename|trunc:8}", "app", "app"},
+		"trunc unicode":  {"{basename|trunc:3}", "Zürich", "Zür"},
+		"pad":            {"{instance|pad:3:0}", "1", "001"},
+		"pad default":    {"{instance|pad:4}", "12", "0012"},
+		"pad longer":     {"{instance|pad:2}", "123", "123"},
+		"replace":        {"{application|replace:-:}", "inventory-system", "inventorysystem"},
+		"replace string": {"{application|replace:-:_}", "a-b-c", "a_b_c"},
+		"remove":         {"{basename|remove:-_.}", "my-web_app.v2", "mywebappv2"},
+		"chained":        {"{basename|replace:-:|upper|trunc:5}", "my-web-app", "MYWEB"},
+		"ascii":          {"{region|ascii|lower}", "Zürich", "zurich"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseNamingPattern(testCase.pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			node := parsed.placeholders()[0]
+			if result := applyPatternFilters(testCase.value, node.Filters); result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestParsePatternFilter_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		pattern string
+		column  int
+		message string
+	}{
+		"unknown filter":   {"rg-{basename|title}", 14, "unknown filter \"title\""},
+		"empty filter":     {"rg-{basename||upper}", 14, "empty filter"},
+		"missing argument": {"rg-{basename:short|trunc}", 20, "expects 1 argument(s), got 0"},
+		"extra argument":   {"rg-{basename|upper:x}", 14, "expects no arguments"},
+		"invalid length":   {"rg-{basename|lower|trunc:x}", 20, "must be a positive number"},
+		"invalid padding":  {"{instance|pad:3:00}", 11, "single character"},
+		"empty replace":    {"{app|replace::x}", 6, "cannot be empty"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseNamingPattern(testCase.pattern)
+			var syntaxErr *patternSyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a patternSyntaxError, got %v", err)
+			}
+			if syntaxErr.Column != testCase.column {
+				t.Errorf("expected column %d, got %d (%s)", testCase.column, syntaxErr.Column, syntaxErr)
+			}
+			if !strings.Contains(syntaxErr.Message, testCase.message) {
+				t.Errorf("expected message containing %q, got %q", testCase.message, syntaxErr.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// patternReferences holds the pattern fragments and naming patterns that naming patterns can reference
+type patternReferences struct {
+	// Fragments maps the names of the pattern_fragments to their pattern text
+	Fragments map[string]string
+	// Patterns returns the naming pattern a {@@resource_type} reference refers to, only an exact or wildcard key
+	// matches, see matchResourceTypeKey
+	Patterns func(resourceType string) (string, bool)
+}
+
+// unknownPatternReferenceError is returned when a {@@resource_type} reference doesn't match any naming pattern
+type unknownPatternReferenceError struct {
+	ResourceType string
+	Column       int
+}
+
+// Error implements the error interface
+func (e *unknownPatternReferenceError) Error() string {
+	return fmt.Sprintf("no naming pattern found for resource type %s referenced at column %d, references only match an exact or wildcard key and don't fall back to the default pattern", e.ResourceType, e.Column)
+}
+
+// patternExpansionSummary returns the summary of a diagnostic for an error expanding a pattern, summary is used for
+// errors other than an unknown {@@resource_type} reference
+func patternExpansionSummary(err error, summary string) string {
+	var unknown *unknownPatternReferenceError
+	if errors.As(err, &unknown) {
+		return "Unknown Pattern Reference"
+	}
+	return summary
+}
+
+// parsePatternReference parses the body of a {@fragment} or {@@resource_type} reference
+func parsePatternReference(pattern, body string, column int) (patternNode, error) {
+	kind := fragmentNode
+	name := strings.TrimPrefix(body, "@")
+	if strings.HasPrefix(name, "@") {
+		kind = patternReferenceNode
+		name = strings.TrimPrefix(name, "@")
+	}
+	if !isValidPlaceholderName(name) {
+		return patternNode{}, &patternSyntaxError{
+			Pattern: pattern,
+			Column:  column + 1,
+			Message: fmt.Sprintf("invalid pattern reference %q, expected {@fragment} or {@@resource_type} without a format or filters", body),
+		}
+	}
+	return patternNode{
+		Kind:   kind,
+		Name:   name,
+		Column: column,
+	}, nil
+}
+
+// patternReference returns the canonical notation of a reference, e.g. "{@suffix}" or "{@@azurerm_virtual_network}"
+func patternReference(kind patternNodeKind, name string) string {
+	if kind == patternReferenceNode {
+		return "{@@" + name + "}"
+	}
+	return "{@" + name + "}"
+}
+
+// unexpandedReferenceError is returned when a pattern with references is rendered without expanding it first
+func unexpandedReferenceError(node patternNode) error {
+	return fmt.Errorf("pattern reference %s at column %d has not been expanded", patternReference(node.Kind, node.Name), node.Column)
+}
+
+// expand returns a copy of the pattern in which every {@fragment} and {@@resource_type} reference is replaced by
+// the nodes of the referenced pattern, recursively. The origin is the reference of the pattern itself, e.g.
+// {@@azurerm_subnet}, so that a pattern referencing itself through other patterns is reported as a cycle.
+func (p *namingPattern) expand(references patternReferences, origin string) (*namingPattern, error) {
+	var chain []string
+	if origin != "" {
+		chain = []string{origin}
+	}
+	nodes, err := expandPatternNodes(p.Nodes, references, chain, false)
+	if err != nil {
+		return nil, err
+	}
+	return &namingPattern{Source: p.Source, Nodes: nodes}, nil
+}
+
+// expandPatternNodes replaces the references in nodes by the nodes of the referenced patterns. The chain holds the
+// references that are being expanded, a reference that is already part of the chain is a cycle.
+func expandPatternNodes(nodes []patternNode, references patternReferences, chain []string, inSegment bool) ([]patternNode, error) {
+	result := make([]patternNode, 0, len(nodes))
+	for _, node := range nodes {
+		switch node.Kind {
+		case fragmentNode, patternReferenceNode:
+			reference := patternReference(node.Kind, node.Name)
+			if slices.Contains(chain, reference) {
+				return nil, fmt.Errorf("pattern reference cycle detected: %s", strings.Join(append(slices.Clone(chain), reference), " -> "))
+			}
+
+			var source string
+			var ok bool
+			if node.Kind == fragmentNode {
+				source, ok = references.Fragments[node.Name]
+				if !ok {
+					return nil, fmt.Errorf("pattern fragment %q referenced at column %d is not defined in pattern_fragments", node.Name, node.Column)
+				}
+			} else if references.Patterns != nil {
+				source, ok = references.Patterns(node.Name)
+			}
+			if !ok {
+				return nil, &unknownPatternReferenceError{ResourceType: node.Name, Column: node.Column}
+			}
+
+			parsed, err := parseNamingPattern(source)
+			if err != nil {
+				return nil, fmt.Errorf("%s referenced at column %d is invalid: %w", reference, node.Column, err)
+			}
+			if inSegment && slices.ContainsFunc(parsed.Nodes, func(child patternNode) bool { return child.Kind == optionalNode }) {
+				return nil, fmt.Errorf("%s referenced at column %d contains an optional segment and cannot be used inside an optional segment", reference, node.Column)
+			}
+			children, err := expandPatternNodes(parsed.Nodes, references, append(slices.Clone(chain), reference), inSegment)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, children...)
+		case optionalNode:
+			children, err := expandPatternNodes(node.Children, references, chain, true)
+			if err != nil {
+				return nil, err
+			}
+			node.Children = children
+			result = append(result, node)
+		default:
+			result = append(result, node)
+		}
+	}
+	return result, nil
+}
+
+// stringMapValues returns the known values of a map of strings, such as the pattern_fragments provider attribute
+func stringMapValues(values types.Map) map[string]string {
+	result := make(map[string]string)
+	if values.IsNull() || values.IsUnknown() {
+		return result
+	}
+	for name, value := range values.Elements() {
+		if strVal, ok := value.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
+			result[name] = strVal.ValueString()
+		}
+	}
+	return result
+}
+
+// configuredNamingPatterns returns the naming patterns of the provider configuration keyed by resource type, the
+// additional_naming_patterns take precedence over the patterns of the built-in pattern sets
+func configuredNamingPatterns(config resourcenamingtoolProviderModel) map[string]string {
+	patterns, _ := builtinNamingPatterns(config)
+	for key, pattern := range stringMapValues(config.AdditionalNamingPatterns) {
+		patterns[key] = pattern
+	}
+	return patterns
+}
+
+// configuredPatternReferences returns the pattern fragments and naming patterns of the provider configuration
+func configuredPatternReferences(config resourcenamingtoolProviderModel) patternReferences {
+	patterns := configuredNamingPatterns(config)
+	return patternReferences{
+		Fragments: stringMapValues(config.PatternFragments),
+		Patterns: func(resourceType string) (string, bool) {
+			key, ok := matchResourceTypeKey(patterns, resourceType)
+			return patterns[key], ok
+		},
+	}
+}
+
+// lookupConfiguredNamingPattern returns the naming pattern of a resource type in the provider configuration, both
+// as written and parsed with its references expanded
+func lookupConfiguredNamingPattern(config resourcenamingtoolProviderModel, resourceType string) (string, *namingPattern, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	patterns := configuredNamingPatterns(config)
+	key, ok := lookupResourceTypeKey(patterns, resourceType)
+	if !ok {
+		diags.AddError("Missing Pattern", fmt.Sprintf("No naming pattern found for resource type: %s", resourceType))
+		return "", nil, diags
+	}
+	pattern := patterns[key]
+
+	parsed, err := parseNamingPattern(pattern)
+	if err == nil {
+		parsed, err = parsed.expand(configuredPatternReferences(config), patternReference(patternReferenceNode, resourceType))
+	}
+	if err != nil {
+		diags.AddError(patternExpansionSummary(err, "Invalid Naming Pattern"), fmt.Sprintf("Naming pattern %q for resource type %s is invalid: %s", pattern, resourceType, err.Error()))
+		return "", nil, diags
+	}
+	return pattern, parsed, diags
+}
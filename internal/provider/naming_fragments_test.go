@@ -0,0 +1,131 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNamingPatternExpand(t *testing.T) {
+	references := patternReferences{
+		Fragments: map[string]string{
+			"suffix":   "{environment:short}-{region:short}",
+			"tail":     "{@suffix}[-{instance}]",
+			"self":     "{basename}-{@self}",
+			"ping":     "{@pong}",
+			"pong":     "{@ping}",
+			"optional": "[-{instance}]",
+			"vnet":     "{@@azurerm_virtual_network}",
+		},
+		Patterns: func(resourceType string) (string, bool) {
+			pattern, ok := map[string]string{
+				"azurerm_virtual_network": "vnet-{basename}-{@suffix}",
+				"azurerm_subnet":          "{@@azurerm_virtual_network}-snet",
+				"loop_a":                  "a-{@@loop_b}",
+				"loop_b":                  "b-{@vnet}-{@@loop_a}",
+			}[resourceType]
+			return pattern, ok
+		},
+	}
+	values := map[string]string{"basename": "app", "environment": "prd", "region": "weu"}
+
+	testCases := map[string]struct {
+		pattern  string
+		origin   string
+		expected string
+		message  string
+	}{
+		"fragment": {
+			pattern:  "rg-{basename}-{@suffix}",
+			expected: "rg-app-prd-weu",
+		},
+		"nested fragment with optional segment": {
+			pattern:  "kv-{basename}-{@tail}",
+			expected: "kv-app-prd-weu",
+		},
+		"fragment inside optional segment": {
+			pattern:  "rg-{basename}[-{@suffix}]",
+			expected: "rg-app-prd-weu",
+		},
+		"pattern reference": {
+			pattern:  "{@@azurerm_virtual_network}-snet",
+			origin:   "{@@azurerm_subnet}",
+			expected: "vnet-app-prd-weu-snet",
+		},
+		"pattern reference chain": {
+			pattern:  "nic-{@@azurerm_subnet}",
+			expected: "nic-vnet-app-prd-weu-snet",
+		},
+		"fragment cycle": {
+			pattern: "rg-{@ping}",
+			message: "pattern reference cycle detected: {@ping} -> {@pong} -> {@ping}",
+		},
+		"self reference": {
+			pattern: "rg-{@self}",
+			message: "pattern reference cycle detected: {@self} -> {@self}",
+		},
+		"pattern cycle": {
+			pattern: "a-{@@loop_b}",
+			origin:  "{@@loop_a}",
+			message: "pattern reference cycle detected: {@@loop_a} -> {@@loop_b} -> {@@loop_a}",
+		},
+		"undefined fragment": {
+			pattern: "rg-{@prefix}",
+			message: "pattern fragment \"prefix\" referenced at column 4 is not defined",
+		},
+		"undefined pattern": {
+			pattern: "{@@azurerm_unknown}-x",
+			message: "no naming pattern found for resource type azurerm_unknown referenced at column 1",
+		},
+		"nested optional segment": {
+			pattern: "rg-{basename}[-{@optional}]",
+			message: "{@optional} referenced at column 16 contains an optional segment",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseNamingPattern(testCase.pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			expanded, err := parsed.expand(references, testCase.origin)
+			if testCase.message != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.message) {
+					t.Fatalf("expected error containing %q, got %v", testCase.message, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			result, unresolved, err := expanded.render(func(node patternNode) (string, bool, error) {
+				value, ok := values[node.Name]
+				return value, ok, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(unresolved) > 0 {
+				t.Fatalf("unexpected unresolved placeholders: %s", describePlaceholders(unresolved))
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestNamingPatternRender_UnexpandedReference(t *testing.T) {
+	parsed, err := parseNamingPattern("rg-{basename}-{@suffix}")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, _, err = parsed.render(func(node patternNode) (string, bool, error) {
+		return "app", true, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "{@suffix} at column 15 has not been expanded") {
+		t.Errorf("expected an unexpanded reference error, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// hashPlaceholderName is the name of the placeholder that produces a deterministic digest of the
+// components of a name, e.g. {hash:6} or {hash:6:basename,environment}
+const hashPlaceholderName = "hash"
+
+// maxHashPlaceholderLength is the longest digest a hash placeholder can produce
+const maxHashPlaceholderLength = 32
+
+// isHashPlaceholder reports whether node is a {hash:N} placeholder
+func isHashPlaceholder(node patternNode) bool {
+	return node.Kind == placeholderNode && node.Name == hashPlaceholderName
+}
+
+// parseHashPlaceholderArgs validates the arguments of a hash placeholder: the length of the digest,
+// optionally followed by a comma separated list of the components to digest
+func parseHashPlaceholderArgs(pattern string, args []string, column, argColumn int) error {
+	if len(args) == 0 {
+		return &patternSyntaxError{
+			Pattern: pattern,
+			Column:  column,
+			Message: "hash placeholder requires a length, e.g. {hash:6}",
+		}
+	}
+	if len(args) > 2 {
+		return &patternSyntaxError{
+			Pattern: pattern,
+			Column:  argColumn + len([]rune(args[0])) + len([]rune(args[1])) + 2,
+			Message: fmt.Sprintf("unexpected argument %q, hash placeholders accept a length and a list of components", args[2]),
+		}
+	}
+
+	length, err := strconv.Atoi(args[0])
+	if err != nil || length < 1 || length > maxHashPlaceholderLength {
+		return &patternSyntaxError{
+			Pattern: pattern,
+			Column:  argColumn,
+			Message: fmt.Sprintf("hash length %q must be a number between 1 and %d", args[0], maxHashPlaceholderLength),
+		}
+	}
+
+	if len(args) == 2 {
+		componentColumn := argColumn + len([]rune(args[0])) + 1
+		for _, component := range strings.Split(args[1], ",") {
+			if !isValidPlaceholderName(component) || component == hashPlaceholderName || component == separatorPlaceholderName {
+				return &patternSyntaxError{
+					Pattern: pattern,
+					Column:  componentColumn,
+					Message: fmt.Sprintf("invalid hash component %q, expected a comma separated list of component names", component),
+				}
+			}
+			componentColumn += len([]rune(component)) + 1
+		}
+	}
+	return nil
+}
+
+// hashPlaceholderComponents returns the components digested by a hash placeholder. Without an explicit
+// list, these are all components referenced by the pattern, in order of appearance.
+func hashPlaceholderComponents(node patternNode, pattern *namingPattern) []string {
+	var names []string
+	if len(node.Args) > 1 {
+		names = strings.Split(node.Args[1], ",")
+	} else {
+		for _, placeholder := range pattern.placeholders() {
+			if isComponentPlaceholder(placeholder) {
+				names = append(names, placeholder.Name)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	components := make([]string, 0, len(names))
+	for _, name := range names {
+		component := canonicalComponentName(name)
+		if !seen[component] {
+			seen[component] = true
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// hashPlaceholderValue computes the value of a hash placeholder from the fullname of its components and
+// the seed. When one of the components has no value, it returns that component and false.
+func hashPlaceholderValue(node patternNode, pattern *namingPattern, seed string, resolve func(component string) string) (string, string, bool) {
+	length, _ := strconv.Atoi(node.Args[0])
+
+	// Digest the fullname of each component, so the hash doesn't change when components are abbreviated
+	input := []string{seed}
+	for _, component := range hashPlaceholderComponents(node, pattern) {
+		value := resolve(component)
+		if value == "" {
+			return "", component, false
+		}
+		input = append(input, component+"="+value)
+	}
+	return shortHash(strings.Join(input, "\x00"), length), "", true
+}
+
+// shortHash returns a deterministic lower case base36 digest of value with the given length
+func shortHash(value string, length int) string {
+	sum := sha256.Sum256([]byte(value))
+	digest := new(big.Int).SetBytes(sum[:]).Text(36)
+	if len(digest) < length {
+		digest = strings.Repeat("0", length-len(digest)) + digest
+	}
+	return digest[len(digest)-length:]
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultPatternKey is the key of the catch-all naming pattern used for resource types without a more specific pattern
+const defaultPatternKey = "default"
+
+// isResourceTypeGlob reports whether key is a glob such as "azurerm_*_database" or "aws_*"
+func isResourceTypeGlob(key string) bool {
+	return strings.Contains(key, "*")
+}
+
+// validateResourceTypeKey checks that key is a resource type, a glob of resource types or the default key
+func validateResourceTypeKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("resource type cannot be empty")
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '_' && r != '*' {
+			return fmt.Errorf("resource type %q is invalid, only letters, digits, underscores and * wildcards are allowed", key)
+		}
+	}
+	return nil
+}
+
+// matchResourceTypeGlob reports whether resourceType matches the glob, in which every * matches any sequence of
+// characters, including an empty one
+func matchResourceTypeGlob(glob, resourceType string) bool {
+	parts := strings.Split(glob, "*")
+	if len(parts) == 1 {
+		return glob == resourceType
+	}
+
+	// The first and last part are anchored to the start and end, the parts in between are matched left to right
+	if !strings.HasPrefix(resourceType, parts[0]) {
+		return false
+	}
+	remainder := resourceType[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(remainder, part)
+		if index < 0 {
+			return false
+		}
+		remainder = remainder[index+len(part):]
+	}
+	return len(remainder) >= len(last) && strings.HasSuffix(remainder, last)
+}
+
+// moreSpecificResourceTypeGlob reports whether glob a takes precedence over glob b: the glob with the most literal
+// characters wins, then the glob with the fewest wildcards, then the glob that sorts first
+func moreSpecificResourceTypeGlob(a, b string) bool {
+	literalsA := len(a) - strings.Count(a, "*")
+	literalsB := len(b) - strings.Count(b, "*")
+	if literalsA != literalsB {
+		return literalsA > literalsB
+	}
+	if strings.Count(a, "*") != strings.Count(b, "*") {
+		return strings.Count(a, "*") < strings.Count(b, "*")
+	}
+	return a < b
+}
+
+// matchResourceTypeKey returns the key of entries that matches resourceType exactly or, when there is no exact
+// key, the most specific matching glob. Unlike lookupResourceTypeKey it never falls back to the default key.
+func matchResourceTypeKey[V any](entries map[string]V, resourceType string) (string, bool) {
+	if _, ok := entries[resourceType]; ok {
+		return resourceType, true
+	}
+
+	match := ""
+	for key := range entries {
+		if !isResourceTypeGlob(key) || !matchResourceTypeGlob(key, resourceType) {
+			continue
+		}
+		if match == "" || moreSpecificResourceTypeGlob(key, match) {
+			match = key
+		}
+	}
+	return match, match != ""
+}
+
+// lookupResourceTypeKey returns the key of entries that applies to resourceType. An exact key takes precedence
+// over the most specific matching glob, which takes precedence over the default key.
+func lookupResourceTypeKey[V any](entries map[string]V, resourceType string) (string, bool) {
+	if key, ok := matchResourceTypeKey(entries, resourceType); ok {
+		return key, true
+	}
+	if _, ok := entries[defaultPatternKey]; ok {
+		return defaultPatternKey, true
+	}
+	return "", false
+}
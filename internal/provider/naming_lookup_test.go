@@ -0,0 +1,100 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchResourceTypeGlob(t *testing.T) {
+	testCases := []struct {
+		glob         string
+		resourceType string
+		expected     bool
+	}{
+		{"aws_*", "aws_s3_bucket", true},
+		{"aws_*", "azurerm_resource_group", false},
+		{"azurerm_*_database", "azurerm_mssql_database", true},
+		{"azurerm_*_database", "azurerm_cosmosdb_sql_database", true},
+		{"azurerm_*_database", "azurerm_mssql_database_extended_auditing_policy", false},
+		{"*_database", "google_sql_database", true},
+		{"*", "azurerm_key_vault", true},
+		{"azurerm_*_*_database", "azurerm_cosmosdb_sql_database", true},
+		{"azurerm_*_*_database", "azurerm_mssql_database", false},
+		{"aws_*_bucket_*", "aws_s3_bucket", false},
+		{"aws_s3_bucket", "aws_s3_bucket", true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.glob+" "+testCase.resourceType, func(t *testing.T) {
+			if result := matchResourceTypeGlob(testCase.glob, testCase.resourceType); result != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestLookupResourceTypeKey(t *testing.T) {
+	entries := map[string]string{
+		"default":                "default",
+		"aws_*":                  "aws",
+		"aws_s3_*":               "s3",
+		"azurerm_*_database":     "database",
+		"azurerm_*":              "azurerm",
+		"azurerm_mssql_*":        "mssql",
+		"azurerm_mssql_database": "exact",
+		"google_*_*":             "google two",
+		"google_*_instance":      "google instance",
+	}
+
+	testCases := map[string]string{
+		"azurerm_mssql_database":    "azurerm_mssql_database",
+		"azurerm_postgres_database": "azurerm_*_database",
+		"azurerm_mssql_server":      "azurerm_mssql_*",
+		"azurerm_key_vault":         "azurerm_*",
+		"aws_s3_bucket":             "aws_s3_*",
+		"aws_sqs_queue":             "aws_*",
+		"google_compute_instance":   "google_*_instance",
+		"google_storage_bucket":     "google_*_*",
+		"oci_core_instance":         "default",
+	}
+
+	for resourceType, expected := range testCases {
+		t.Run(resourceType, func(t *testing.T) {
+			key, ok := lookupResourceTypeKey(entries, resourceType)
+			if !ok || key != expected {
+				t.Errorf("expected %q, got %q", expected, key)
+			}
+		})
+	}
+
+	if key, ok := lookupResourceTypeKey(map[string]string{"aws_*": "aws"}, "oci_core_instance"); ok {
+		t.Errorf("expected no match without a default key, got %q", key)
+	}
+}
+
+func TestMoreSpecificResourceTypeGlob(t *testing.T) {
+	// Globs with the same number of literal characters are ordered by the number of wildcards, then by name
+	globs := []string{"b*_x", "a*_x", "a**_x"}
+	best := ""
+	for _, glob := range globs {
+		if best == "" || moreSpecificResourceTypeGlob(glob, best) {
+			best = glob
+		}
+	}
+	if best != "a*_x" {
+		t.Errorf("expected %q, got %q", "a*_x", best)
+	}
+}
+
+func TestValidateResourceTypeKey(t *testing.T) {
+	for _, key := range []string{"azurerm_resource_group", "aws_*", "azurerm_*_database", "default"} {
+		if err := validateResourceTypeKey(key); err != nil {
+			t.Errorf("unexpected error for %q: %s", key, err)
+		}
+	}
+	if err := validateResourceTypeKey("aws-s3?"); err == nil || !strings.Contains(err.Error(), "only letters, digits, underscores and * wildcards are allowed") {
+		t.Errorf("expected an invalid resource type error, got %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// patternMatcher holds what is needed to match a name against the naming pattern it was generated from
+type patternMatcher struct {
+	// Separator is the value of the {sep} placeholder
+	Separator string
+	// Characters is the character class of the characters that component values can contain, e.g. "a-z0-9", the
+	// allowed_characters of the resource type. Values can contain any character when it is empty.
+	Characters string
+	// Render applies the transformations of name generation, such as filters and sanitization, to the value of a
+	// placeholder, so that known values can be compared with the text found in a name
+	Render func(node patternNode, value string) string
+	// Known holds the known values of each component, keyed by fullname, shortcode and char, e.g. the provider
+	// defaults. A known value that renders to the text found in a name is used to recover the other representations.
+	Known map[string][]map[string]string
+}
+
+// nameMismatchError is returned by match when the name doesn't follow the naming pattern, as opposed to a pattern
+// that cannot be matched at all
+type nameMismatchError struct {
+	Name string
+	// Reason completes the sentence "name ... ", e.g. "doesn't match the naming pattern ..."
+	Reason string
+}
+
+// Error implements the error interface
+func (e *nameMismatchError) Error() string {
+	return fmt.Sprintf("name %q %s", e.Name, e.Reason)
+}
+
+// knownRepresentation returns the value of a known component in the given format, the shortcode and char are
+// derived from the prefix of the fullname when they are not set and the fullname has no abbreviation, the same way
+// name generation derives them
+func knownRepresentation(values map[string]string, format string) string {
+	if value := values[componentAttributeForFormat(format)]; value != "" {
+		return value
+	}
+	switch format {
+	case "short":
+		return runePrefix(values["fullname"], 3)
+	case "char":
+		return runePrefix(values["fullname"], 1)
+	}
+	return ""
+}
+
+// match decomposes name into the values of the components of the pattern. Placeholders of components with known
+// values only match these values, when the name doesn't match, the placeholders are allowed to match any value one
+// by one from right to left. Placeholders without known values match the shortest value that lets the rest of the
+// name match, so names with separators inside component values may be split ambiguously. It returns the components
+// keyed by fullname, shortcode and char; representations that are not part of the name are recovered from a
+// matching known value.
+func (p *namingPattern) match(name string, matcher patternMatcher) (map[string]map[string]string, error) {
+	var submatches []int
+	var expression *patternExpression
+	restricted := countKnownPlaceholders(p.Nodes, matcher)
+	for ; submatches == nil; restricted-- {
+		if restricted < 0 {
+			return nil, &nameMismatchError{Name: name, Reason: fmt.Sprintf("doesn't match the naming pattern %q", p.Source)}
+		}
+		expression = &patternExpression{Matcher: matcher, Restricted: restricted}
+		if err := expression.write(p.Nodes); err != nil {
+			return nil, err
+		}
+		compiled, err := regexp.Compile("^" + expression.String() + "$")
+		if err != nil {
+			return nil, fmt.Errorf("cannot match names against the pattern: %w", err)
+		}
+		submatches = compiled.FindStringSubmatchIndex(name)
+	}
+
+	components := make(map[string]map[string]string)
+	for i, node := range expression.Captures {
+		start, end := submatches[2*i+2], submatches[2*i+3]
+		if start < 0 {
+			// The placeholder is part of an optional segment that is not present in the name
+			continue
+		}
+		component := canonicalComponentName(node.Name)
+		values := matchedComponentValues(node, component, name[start:end], matcher)
+		if components[component] == nil {
+			components[component] = make(map[string]string)
+		}
+		for _, attribute := range []string{"fullname", "shortcode", "char"} {
+			value, ok := values[attribute]
+			if !ok || value == "" {
+				continue
+			}
+			if existing := components[component][attribute]; existing != "" && existing != value {
+				return nil, &nameMismatchError{Name: name, Reason: fmt.Sprintf("has conflicting values for component %s: %s %q at column %d doesn't match %q", component, node.Text, value, node.Column, existing)}
+			}
+			components[component][attribute] = value
+		}
+	}
+	return components, nil
+}
+
+// patternExpression builds the regular expression matching the names of a pattern
+type patternExpression struct {
+	strings.Builder
+	Matcher patternMatcher
+	// Restricted is the number of placeholders with known values, counted from the left, that only match their
+	// known values, the other placeholders match any text
+	Restricted int
+	// Captures holds the placeholders of the capturing groups, in the order of the groups
+	Captures []patternNode
+	// known is the number of placeholders with known values written so far
+	known int
+}
+
+// countKnownPlaceholders returns the number of placeholders in nodes whose component has known values
+func countKnownPlaceholders(nodes []patternNode, matcher patternMatcher) int {
+	count := 0
+	for _, node := range nodes {
+		switch {
+		case node.Kind == optionalNode:
+			count += countKnownPlaceholders(node.Children, matcher)
+		case isComponentPlaceholder(node) && !isNumericPlaceholderFormat(placeholderFormat(node)) && node.Offset == 0:
+			if len(knownPlaceholderValues(node, matcher)) > 0 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// write appends the regular expression matching nodes, every component placeholder is a capturing group
+func (e *patternExpression) write(nodes []patternNode) error {
+	for _, node := range nodes {
+		switch node.Kind {
+		case literalNode:
+			e.WriteString(regexp.QuoteMeta(node.Text))
+		case optionalNode:
+			e.WriteString("(?:")
+			if err := e.write(node.Children); err != nil {
+				return err
+			}
+			e.WriteString(")?")
+		case fragmentNode, patternReferenceNode:
+			return unexpandedReferenceError(node)
+		case placeholderNode:
+			switch {
+			case isSeparatorPlaceholder(node):
+				e.WriteString(regexp.QuoteMeta(e.Matcher.Render(node, e.Matcher.Separator)))
+			case isHashPlaceholder(node):
+				length, _ := strconv.Atoi(node.Args[0])
+				fmt.Fprintf(e, "[0-9a-zA-Z]{%d}", length)
+			case isNumericPlaceholderFormat(placeholderFormat(node)) || node.Offset != 0:
+				e.Captures = append(e.Captures, node)
+				e.WriteString("(-?[0-9]+)")
+			default:
+				e.Captures = append(e.Captures, node)
+				candidates := knownPlaceholderValues(node, e.Matcher)
+				if len(candidates) == 0 {
+					e.WriteString("(" + e.anyValue() + ")")
+					continue
+				}
+				restricted := e.known < e.Restricted
+				e.known++
+				e.WriteString("(")
+				for i, candidate := range candidates {
+					if i > 0 {
+						e.WriteString("|")
+					}
+					e.WriteString(regexp.QuoteMeta(candidate))
+				}
+				if !restricted {
+					e.WriteString("|" + e.anyValue())
+				}
+				e.WriteString(")")
+			}
+		}
+	}
+	return nil
+}
+
+// anyValue returns the regular expression matching the shortest value of a component
+func (e *patternExpression) anyValue() string {
+	if e.Matcher.Characters == "" {
+		return ".+?"
+	}
+	return "[" + e.Matcher.Characters + "]+?"
+}
+
+// knownPlaceholderValues returns the rendered known values of the component of a placeholder, longest first so a
+// known value is preferred over its own prefixes
+func knownPlaceholderValues(node patternNode, matcher patternMatcher) []string {
+	format := placeholderFormat(node)
+	var candidates []string
+	for _, values := range matcher.Known[canonicalComponentName(node.Name)] {
+		if value := knownRepresentation(values, format); value != "" {
+			if rendered := matcher.Render(node, value); rendered != "" && !slices.Contains(candidates, rendered) {
+				candidates = append(candidates, rendered)
+			}
+		}
+	}
+	slices.SortFunc(candidates, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+	return candidates
+}
+
+// matchedComponentValues returns the representations of a component that can be derived from the text matched by
+// one of its placeholders
+func matchedComponentValues(node patternNode, component, text string, matcher patternMatcher) map[string]string {
+	format := placeholderFormat(node)
+	if isNumericPlaceholderFormat(format) || node.Offset != 0 {
+		number, err := strconv.Atoi(text)
+		if err != nil {
+			return map[string]string{"fullname": text}
+		}
+		// Prefer the known value with the same number, which keeps leading zeros such as "00001"
+		for _, values := range matcher.Known[component] {
+			if known, err := strconv.Atoi(strings.TrimSpace(values["fullname"])); err == nil && known == number-node.Offset {
+				return values
+			}
+		}
+		return map[string]string{"fullname": strconv.Itoa(number - node.Offset)}
+	}
+
+	for _, values := range matcher.Known[component] {
+		if value := knownRepresentation(values, format); value != "" && matcher.Render(node, value) == text {
+			recovered := make(map[string]string)
+			for _, attribute := range []string{"full", "short", "char"} {
+				recovered[componentAttributeForFormat(attribute)] = knownRepresentation(values, attribute)
+			}
+			return recovered
+		}
+	}
+	return map[string]string{componentAttributeForFormat(format): text}
+}
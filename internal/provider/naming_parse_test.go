@@ -0,0 +1,175 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testPatternMatcher returns a matcher that applies the filters of the placeholders and knows the given values
+func testPatternMatcher(known map[string][]map[string]string) patternMatcher {
+	return patternMatcher{
+		Separator: "-",
+		Render: func(node patternNode, value string) string {
+			return applyPatternFilters(value, node.Filters)
+		},
+		Known: known,
+	}
+}
+
+func TestNamingPatternMatch(t *testing.T) {
+	known := map[string][]map[string]string{
+		"environment": {{"fullname": "production", "shortcode": "prd", "char": "p"}},
+		"region":      {{"fullname": "westeurope", "shortcode": "we"}, {"fullname": "northeurope", "shortcode": "ne"}},
+		"instance":    {{"fullname": "00001", "shortcode": "001", "char": "1"}},
+	}
+	testCases := map[string]struct {
+		pattern  string
+		name     string
+		expected map[string]map[string]string
+	}{
+		"unknown values": {
+			pattern: "rg-{basename}-{workload:short}",
+			name:    "rg-payroll-api",
+			expected: map[string]map[string]string{
+				"basename": {"fullname": "payroll"},
+				"workload": {"shortcode": "api"},
+			},
+		},
+		"known values are recovered": {
+			pattern: "rg-{basename}-{env}-{r}",
+			name:    "rg-payroll-prd-n",
+			expected: map[string]map[string]string{
+				"basename":    {"fullname": "payroll"},
+				"environment": {"fullname": "production", "shortcode": "prd", "char": "p"},
+				"region":      {"fullname": "northeurope", "shortcode": "ne", "char": "n"},
+			},
+		},
+		"unknown value of a component with known values": {
+			pattern: "rg-{basename}-{env}-{r}",
+			name:    "rg-payroll-dev-w",
+			expected: map[string]map[string]string{
+				"basename":    {"fullname": "payroll"},
+				"environment": {"shortcode": "dev"},
+				"region":      {"fullname": "westeurope", "shortcode": "we", "char": "w"},
+			},
+		},
+		"known values without separators": {
+			pattern: "st{basename}{environment:short}{region:short}{instance:short}",
+			name:    "stpayrollprdwe001",
+			expected: map[string]map[string]string{
+				"basename":    {"fullname": "payroll"},
+				"environment": {"fullname": "production", "shortcode": "prd", "char": "p"},
+				"region":      {"fullname": "westeurope", "shortcode": "we", "char": "w"},
+				"instance":    {"fullname": "00001", "shortcode": "001", "char": "1"},
+			},
+		},
+		"separator and filters": {
+			pattern: "{basename|upper}{sep}{environment:short|upper}",
+			name:    "PAYROLL-PRD",
+			expected: map[string]map[string]string{
+				"basename":    {"fullname": "PAYROLL"},
+				"environment": {"fullname": "production", "shortcode": "prd", "char": "p"},
+			},
+		},
+		"numeric placeholder": {
+			pattern: "vm-{basename}-{instance+1:%02d}",
+			name:    "vm-web-03",
+			expected: map[string]map[string]string{
+				"basename": {"fullname": "web"},
+				"instance": {"fullname": "2"},
+			},
+		},
+		"numeric placeholder with a known value": {
+			pattern: "vm-{basename}-{instance:%03d}",
+			name:    "vm-web-001",
+			expected: map[string]map[string]string{
+				"basename": {"fullname": "web"},
+				"instance": {"fullname": "00001", "shortcode": "001", "char": "1"},
+			},
+		},
+		"hash": {
+			pattern: "st{basename}{hash:4}",
+			name:    "stweb9l0l",
+			expected: map[string]map[string]string{
+				"basename": {"fullname": "web"},
+			},
+		},
+		"optional segment present": {
+			pattern: "rg-{basename}[-{department}]-{env}",
+			name:    "rg-payroll-finance-prd",
+			expected: map[string]map[string]string{
+				"basename":    {"fullname": "payroll"},
+				"department":  {"fullname": "finance"},
+				"environment": {"fullname": "production", "shortcode": "prd", "char": "p"},
+			},
+		},
+		"optional segment left out": {
+			pattern: "rg-{basename}[-{department}]-{env}",
+			name:    "rg-payroll-prd",
+			expected: map[string]map[string]string{
+				"basename":    {"fullname": "payroll"},
+				"environment": {"fullname": "production", "shortcode": "prd", "char": "p"},
+			},
+		},
+		"same component twice": {
+			pattern: "{env}-{e}-{basename}",
+			name:    "prd-p-web",
+			expected: map[string]map[string]string{
+				"environment": {"fullname": "production", "shortcode": "prd", "char": "p"},
+				"basename":    {"fullname": "web"},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseNamingPattern(testCase.pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			components, err := parsed.match(testCase.name, testPatternMatcher(known))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(components, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, components)
+			}
+		})
+	}
+}
+
+func TestNamingPatternMatch_Errors(t *testing.T) {
+	known := map[string][]map[string]string{
+		"environment": {{"fullname": "production", "shortcode": "prd", "char": "p"}},
+	}
+	testCases := map[string]struct {
+		pattern string
+		name    string
+		message string
+	}{
+		"literal mismatch":   {"rg-{basename}", "st-payroll", "name \"st-payroll\" doesn't match the naming pattern \"rg-{basename}\""},
+		"missing component":  {"rg-{basename}-{env}", "rg-payroll", "doesn't match the naming pattern"},
+		"hash length":        {"st{basename}{hash:4}", "stw9l", "doesn't match the naming pattern"},
+		"invalid characters": {"st{basename}{environment:short}", "st-payrollprd", "doesn't match the naming pattern"},
+		"conflicting values": {"{env}-{e}-{basename}", "prd-x-web",
+			"name \"prd-x-web\" has conflicting values for component environment: {e} \"x\" at column 7 doesn't match \"p\""},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseNamingPattern(testCase.pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			matcher := testPatternMatcher(known)
+			matcher.Characters = "a-z0-9"
+			_, err = parsed.match(testCase.name, matcher)
+			if err == nil || !strings.Contains(err.Error(), testCase.message) {
+				t.Errorf("expected error containing %q, got %v", testCase.message, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,471 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// patternNodeKind identifies the kind of a node in a parsed naming pattern
+type patternNodeKind int
+
+const (
+	// literalNode is a run of literal text that is copied verbatim into the name
+	literalNode patternNodeKind = iota
+	// placeholderNode is a {component} or {component:format} placeholder
+	placeholderNode
+	// optionalNode is a [...] segment that is dropped when one of its placeholders cannot be resolved
+	optionalNode
+	// fragmentNode is a {@fragment} reference to one of the pattern_fragments
+	fragmentNode
+	// patternReferenceNode is a {@@resource_type} reference to the naming pattern of another resource type
+	patternReferenceNode
+)
+
+// patternNode is a single element of a parsed naming pattern
+type patternNode struct {
+	Kind patternNodeKind
+	// Text holds the literal text for literal nodes, or the raw placeholder (including braces) for placeholder nodes
+	Text string
+	// Name is the component name or alias referenced by a placeholder, or the fragment or resource type
+	// referenced by a pattern reference
+	Name string
+	// Args holds the colon separated arguments following the name, e.g. "short" in {region:short}
+	Args []string
+	// Offset is added to the numeric value of the component, e.g. 1 in {instance+1:%02d}
+	Offset int
+	// Filters holds the transform filters applied to the resolved value, e.g. "upper" in {basename|upper}
+	Filters []patternFilter
+	// Column is the 1-based character position of the node in the pattern
+	Column int
+	// Children holds the nodes inside an optional segment
+	Children []patternNode
+}
+
+// namingPattern is the parsed representation (AST) of a naming pattern
+type namingPattern struct {
+	Source string
+	Nodes  []patternNode
+}
+
+// patternSyntaxError describes a syntax error in a naming pattern and the column where it was found
+type patternSyntaxError struct {
+	Pattern string
+	Column  int
+	Message string
+}
+
+// Error implements the error interface
+func (e *patternSyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at column %d: %s", e.Column, e.Message)
+}
+
+// parseNamingPattern tokenizes and parses a naming pattern into a namingPattern.
+//
+// Placeholders are written as {name} or {name:arg}, optionally followed by transform filters
+// such as {name:arg|lower|trunc:8}. Numeric components can be formatted and offset, e.g. {instance+1:%03d}.
+// The {hash:N} and {hash:N:component,...} placeholders produce
+// a deterministic digest of the components of the name, the {sep} placeholder is replaced by the separator
+// of the resource type. {@fragment} and {@@resource_type} reference a pattern fragment or the pattern of another
+// resource type, these are expanded with namingPattern.expand before the pattern is rendered. Text enclosed in square brackets forms an
+// optional segment, e.g. "[-{instance}]", which is left out of the name when one of its
+// placeholders has no value. Literal braces and brackets are escaped by doubling them: "{{"
+// produces "{", "}}" produces "}", "[[" produces "[" and "]]" produces "]".
+func parseNamingPattern(pattern string) (*namingPattern, error) {
+	runes := []rune(pattern)
+	parsed := &namingPattern{Source: pattern}
+
+	// Nodes are appended to the open optional segment, if any, or to the pattern itself
+	var segment *patternNode
+	appendNode := func(node patternNode) {
+		if segment != nil {
+			segment.Children = append(segment.Children, node)
+		} else {
+			parsed.Nodes = append(parsed.Nodes, node)
+		}
+	}
+
+	var literal strings.Builder
+	literalColumn := 0
+
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			appendNode(patternNode{
+				Kind:   literalNode,
+				Text:   literal.String(),
+				Column: literalColumn,
+			})
+			literal.Reset()
+		}
+	}
+	appendLiteral := func(r rune, column int) {
+		if literal.Len() == 0 {
+			literalColumn = column
+		}
+		literal.WriteRune(r)
+	}
+
+	for i := 0; i < len(runes); i++ {
+		column := i + 1
+		switch runes[i] {
+		case '{':
+			// Escaped literal brace
+			if i+1 < len(runes) && runes[i+1] == '{' {
+				appendLiteral('{', column)
+				i++
+				continue
+			}
+
+			// Find the closing brace of this placeholder
+			end := -1
+			for j := i + 1; j < len(runes); j++ {
+				if runes[j] == '{' {
+					return nil, &patternSyntaxError{Pattern: pattern, Column: j + 1, Message: "unexpected '{' inside placeholder"}
+				}
+				if runes[j] == '}' {
+					end = j
+					break
+				}
+			}
+			if end == -1 {
+				return nil, &patternSyntaxError{Pattern: pattern, Column: column, Message: "unterminated placeholder, missing '}'"}
+			}
+
+			node, err := parsePlaceholder(pattern, string(runes[i+1:end]), column)
+			if err != nil {
+				return nil, err
+			}
+			node.Text = string(runes[i : end+1])
+
+			flushLiteral()
+			appendNode(node)
+			i = end
+		case '}':
+			// Escaped literal brace
+			if i+1 < len(runes) && runes[i+1] == '}' {
+				appendLiteral('}', column)
+				i++
+				continue
+			}
+			return nil, &patternSyntaxError{Pattern: pattern, Column: column, Message: "unexpected '}', use '}}' for a literal brace"}
+		case '[':
+			// Escaped literal bracket
+			if i+1 < len(runes) && runes[i+1] == '[' {
+				appendLiteral('[', column)
+				i++
+				continue
+			}
+			if segment != nil {
+				return nil, &patternSyntaxError{Pattern: pattern, Column: column, Message: "optional segments cannot be nested"}
+			}
+			flushLiteral()
+			segment = &patternNode{Kind: optionalNode, Column: column}
+		case ']':
+			// Escaped literal bracket
+			if i+1 < len(runes) && runes[i+1] == ']' {
+				appendLiteral(']', column)
+				i++
+				continue
+			}
+			if segment == nil {
+				return nil, &patternSyntaxError{Pattern: pattern, Column: column, Message: "unexpected ']', use ']]' for a literal bracket"}
+			}
+			flushLiteral()
+			segment.Text = string(runes[segment.Column-1 : i+1])
+			closed := *segment
+			segment = nil
+			appendNode(closed)
+		default:
+			appendLiteral(runes[i], column)
+		}
+	}
+	if segment != nil {
+		return nil, &patternSyntaxError{Pattern: pattern, Column: segment.Column, Message: "unterminated optional segment, missing ']'"}
+	}
+	flushLiteral()
+
+	return parsed, nil
+}
+
+// parsePlaceholder parses the body of a placeholder (the text between the braces)
+func parsePlaceholder(pattern, body string, column int) (patternNode, error) {
+	if body == "" {
+		return patternNode{}, &patternSyntaxError{Pattern: pattern, Column: column, Message: "empty placeholder"}
+	}
+	if strings.HasPrefix(body, "@") {
+		return parsePatternReference(pattern, body, column)
+	}
+
+	// The reference to the component is followed by optional filters separated by pipes
+	sections := strings.Split(body, "|")
+	parts := strings.Split(sections[0], ":")
+	reference := parts[0]
+	name, offset, err := parsePlaceholderOffset(pattern, reference, column)
+	if err != nil {
+		return patternNode{}, err
+	}
+	if !isValidPlaceholderName(name) {
+		return patternNode{}, &patternSyntaxError{
+			Pattern: pattern,
+			Column:  column + 1,
+			Message: fmt.Sprintf("invalid component name %q, only letters, digits and underscores are allowed", name),
+		}
+	}
+
+	args := parts[1:]
+	argColumn := column + len([]rune(reference)) + 2
+	if name == hashPlaceholderName {
+		if err := parseHashPlaceholderArgs(pattern, args, column, argColumn); err != nil {
+			return patternNode{}, err
+		}
+	} else if name == separatorPlaceholderName && (len(args) > 0 || offset != 0) {
+		return patternNode{}, &patternSyntaxError{
+			Pattern: pattern,
+			Column:  column + 1 + len(separatorPlaceholderName),
+			Message: "the separator placeholder {sep} doesn't accept a format or an offset",
+		}
+	} else if len(args) > 1 {
+		return patternNode{}, &patternSyntaxError{
+			Pattern: pattern,
+			Column:  argColumn + len([]rune(args[0])) + 1,
+			Message: fmt.Sprintf("unexpected argument %q, placeholders accept a single format", args[1]),
+		}
+	} else if len(args) == 1 && !isValidPlaceholderFormat(args[0]) && !isNumericPlaceholderFormat(args[0]) {
+		return patternNode{}, &patternSyntaxError{
+			Pattern: pattern,
+			Column:  argColumn,
+			Message: fmt.Sprintf("unknown format %q, expected full, short, char or a numeric format such as %%03d", args[0]),
+		}
+	}
+
+	var filters []patternFilter
+	filterColumn := column + len([]rune(sections[0])) + 2
+	for _, section := range sections[1:] {
+		filter, err := parsePatternFilter(pattern, section, filterColumn)
+		if err != nil {
+			return patternNode{}, err
+		}
+		filters = append(filters, filter)
+		filterColumn += len([]rune(section)) + 1
+	}
+
+	return patternNode{
+		Kind:    placeholderNode,
+		Name:    name,
+		Args:    args,
+		Offset:  offset,
+		Filters: filters,
+		Column:  column,
+	}, nil
+}
+
+// parsePlaceholderOffset splits a component reference such as "instance+1" into the component name and
+// the offset that is added to its numeric value
+func parsePlaceholderOffset(pattern, reference string, column int) (string, int, error) {
+	index := strings.IndexAny(reference, "+-")
+	if index <= 0 {
+		return reference, 0, nil
+	}
+
+	offset, err := strconv.Atoi(reference[index:])
+	if err != nil {
+		return "", 0, &patternSyntaxError{
+			Pattern: pattern,
+			Column:  column + 1 + len([]rune(reference[:index])),
+			Message: fmt.Sprintf("invalid offset %q, expected a whole number such as +1", reference[index:]),
+		}
+	}
+	return reference[:index], offset, nil
+}
+
+// isNumericPlaceholderFormat reports whether format is a numeric format such as %d, %3d or %03d
+func isNumericPlaceholderFormat(format string) bool {
+	return numericPlaceholderFormat.MatchString(format)
+}
+
+// numericPlaceholderFormat matches the supported numeric formats, a minimum width optionally padded with zeros
+var numericPlaceholderFormat = regexp.MustCompile(`^%0?[0-9]{0,2}d$`)
+
+// isValidPlaceholderFormat reports whether format is one of the supported component representations
+func isValidPlaceholderFormat(format string) bool {
+	return format == "full" || format == "short" || format == "char"
+}
+
+// isValidPlaceholderName reports whether name is a valid component name or alias
+func isValidPlaceholderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// placeholders returns all placeholder nodes of the pattern, including those inside optional
+// segments, in order of appearance
+func (p *namingPattern) placeholders() []patternNode {
+	return collectPlaceholders(p.Nodes)
+}
+
+// collectPlaceholders returns the placeholder nodes of nodes and their children
+func collectPlaceholders(nodes []patternNode) []patternNode {
+	result := make([]patternNode, 0, len(nodes))
+	for _, node := range nodes {
+		switch node.Kind {
+		case placeholderNode:
+			result = append(result, node)
+		case optionalNode:
+			result = append(result, collectPlaceholders(node.Children)...)
+		}
+	}
+	return result
+}
+
+// placeholderResolver returns the value for a placeholder node. It returns false when the
+// placeholder cannot be resolved, or an error when the placeholder itself is invalid.
+type placeholderResolver func(node patternNode) (string, bool, error)
+
+// patternSeparators are the characters that are collapsed when an optional segment between
+// them is dropped or a value between them is empty, so that "rg-{basename}-[{instance}]-{region}"
+// never produces "rg-app--we"
+const patternSeparators = "-_."
+
+// render substitutes all placeholders of the pattern in a single pass. Resolved values are
+// inserted as literals and are never scanned for placeholders again. Optional segments with
+// an unresolved placeholder are dropped, the placeholders that could not be resolved outside
+// of optional segments are returned in order of appearance. The separators around dropped
+// segments and empty values are collapsed.
+func (p *namingPattern) render(resolve placeholderResolver) (string, []patternNode, error) {
+	var result strings.Builder
+	var unresolved []patternNode
+	// Byte offsets in the result where an optional segment was dropped or a value was empty
+	var dropped []int
+
+	for _, node := range p.Nodes {
+		switch node.Kind {
+		case literalNode:
+			result.WriteString(node.Text)
+		case placeholderNode:
+			value, ok, err := resolve(node)
+			if err != nil {
+				return "", nil, err
+			}
+			if !ok {
+				unresolved = append(unresolved, node)
+				continue
+			}
+			if value == "" {
+				dropped = append(dropped, result.Len())
+			}
+			result.WriteString(value)
+		case optionalNode:
+			segment, empty, complete, err := renderOptionalSegment(node, resolve)
+			if err != nil {
+				return "", nil, err
+			}
+			if !complete {
+				dropped = append(dropped, result.Len())
+				continue
+			}
+			for _, offset := range empty {
+				dropped = append(dropped, result.Len()+offset)
+			}
+			result.WriteString(segment)
+		case fragmentNode, patternReferenceNode:
+			return "", nil, unexpandedReferenceError(node)
+		}
+	}
+
+	return collapseSeparators(result.String(), dropped), unresolved, nil
+}
+
+// renderOptionalSegment renders the children of an optional segment together with the byte offsets
+// in the segment where a value was empty, it returns false when one of the placeholders in the
+// segment cannot be resolved
+func renderOptionalSegment(node patternNode, resolve placeholderResolver) (string, []int, bool, error) {
+	var segment strings.Builder
+	var empty []int
+	for _, child := range node.Children {
+		switch child.Kind {
+		case literalNode:
+			segment.WriteString(child.Text)
+		case placeholderNode:
+			value, ok, err := resolve(child)
+			if err != nil {
+				return "", nil, false, err
+			}
+			if !ok {
+				return "", nil, false, nil
+			}
+			if value == "" {
+				empty = append(empty, segment.Len())
+			}
+			segment.WriteString(value)
+		case fragmentNode, patternReferenceNode:
+			return "", nil, false, unexpandedReferenceError(child)
+		}
+	}
+	return segment.String(), empty, true, nil
+}
+
+// collapseSeparators removes the doubled, leading or trailing separators that remain at the
+// offsets where optional segments were dropped or values were empty. Separators elsewhere in
+// the name, including the ones inside values, are left untouched.
+func collapseSeparators(name string, offsets []int) string {
+	// Walk the offsets backwards so earlier offsets remain valid after removing characters
+	for i := len(offsets) - 1; i >= 0; i-- {
+		offset := offsets[i]
+		switch {
+		case offset > 0 && offset < len(name) && isPatternSeparator(name[offset-1]) && isPatternSeparator(name[offset]):
+			name = name[:offset] + name[offset+1:]
+		case offset == 0 && len(name) > 0 && isPatternSeparator(name[0]):
+			name = name[1:]
+		case offset > 0 && offset == len(name) && isPatternSeparator(name[offset-1]):
+			name = name[:offset-1]
+		}
+	}
+	return name
+}
+
+// patternMetacharacters are the characters with a meaning in naming patterns. Values are always inserted as
+// literals, values containing them are rejected so that a generated name never looks like a (partial) pattern.
+const patternMetacharacters = "{}[]"
+
+// findPatternMetacharacter returns the first of the patternMetacharacters in value, or an empty string
+func findPatternMetacharacter(value string) string {
+	if index := strings.IndexAny(value, patternMetacharacters); index >= 0 {
+		return value[index : index+1]
+	}
+	return ""
+}
+
+// findComponentMetacharacter returns the first attribute of a component (fullname, shortcode or char) whose value
+// contains one of the patternMetacharacters, together with the value and the metacharacter
+func findComponentMetacharacter(values map[string]string) (string, string, string) {
+	for _, attribute := range []string{"fullname", "shortcode", "char"} {
+		if metacharacter := findPatternMetacharacter(values[attribute]); metacharacter != "" {
+			return attribute, values[attribute], metacharacter
+		}
+	}
+	return "", "", ""
+}
+
+// isPatternSeparator reports whether c is one of the patternSeparators
+func isPatternSeparator(c byte) bool {
+	return strings.IndexByte(patternSeparators, c) >= 0
+}
+
+// describePlaceholders formats placeholder nodes for use in error messages, e.g. "{instance} (column 12)"
+func describePlaceholders(nodes []patternNode) string {
+	descriptions := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		descriptions = append(descriptions, fmt.Sprintf("%s (column %d)", node.Text, node.Column))
+	}
+	return strings.Join(descriptions, ", ")
+}
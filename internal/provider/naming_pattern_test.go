@@ -0,0 +1,218 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseNamingPattern(t *testing.T) {
+	parsed, err := parseNamingPattern("rg-{basename}-{environment:short}-{{x}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []patternNode{
+		{Kind: literalNode, Text: "rg-", Column: 1},
+		{Kind: placeholderNode, Text: "{basename}", Name: "basename", Args: []string{}, Column: 4},
+		{Kind: literalNode, Text: "-", Column: 14},
+		{Kind: placeholderNode, Text: "{environment:short}", Name: "environment", Args: []string{"short"}, Column: 15},
+		{Kind: literalNode, Text: "-{x}", Column: 34},
+	}
+	if len(parsed.Nodes) != len(expected) {
+		t.Fatalf("expected %d nodes, got %d: %#v", len(expected), len(parsed.Nodes), parsed.Nodes)
+	}
+	for i, node := range parsed.Nodes {
+		want := expected[i]
+		if node.Kind != want.Kind || node.Text != want.Text || node.Name != want.Name || node.Column != want.Column ||
+			strings.Join(node.Args, ":") != strings.Join(want.Args, ":") {
+			t.Errorf("node %d: expected %#v, got %#v", i, want, node)
+		}
+	}
+}
+
+func TestParseNamingPattern_NumericPlaceholders(t *testing.T) {
+	testCases := map[string]struct {
+		offset int
+		args   []string
+	}{
+		"{instance:%03d}":   {0, []string{"%03d"}},
+		"{instance+1:%02d}": {1, []string{"%02d"}},
+		"{instance-1}":      {-1, []string{}},
+		"{i+10:%d}":         {10, []string{"%d"}},
+	}
+
+	for pattern, testCase := range testCases {
+		t.Run(pattern, func(t *testing.T) {
+			parsed, err := parseNamingPattern(pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			node := parsed.Nodes[0]
+			if node.Offset != testCase.offset || strings.Join(node.Args, ":") != strings.Join(testCase.args, ":") {
+				t.Errorf("expected offset %d and args %v, got %d and %v", testCase.offset, testCase.args, node.Offset, node.Args)
+			}
+			if node.Text != pattern {
+				t.Errorf("expected text %q, got %q", pattern, node.Text)
+			}
+		})
+	}
+}
+
+func TestParseNamingPattern_SyntaxErrors(t *testing.T) {
+	testCases := map[string]struct {
+		pattern string
+		column  int
+		message string
+	}{
+		"unterminated":      {"rg-{basename", 4, "unterminated placeholder"},
+		"stray close":       {"rg-basename}", 12, "unexpected '}'"},
+		"nested open":       {"rg-{base{name}", 9, "unexpected '{'"},
+		"empty placeholder": {"rg-{}-x", 4, "empty placeholder"},
+		"invalid name":      {"rg-{base name}", 5, "invalid component name"},
+		"unknown format":    {"rg-{region:tiny}", 12, "unknown format \"tiny\""},
+		"too many args":     {"rg-{region:short:x}", 18, "unexpected argument \"x\""},
+		"nested segment":    {"rg[-{a}[-{b}]]", 8, "cannot be nested"},
+		"open segment":      {"rg[-{instance}", 3, "unterminated optional segment"},
+		"stray bracket":     {"rg-{instance}]", 14, "unexpected ']'"},
+		"numeric format":    {"vm-{instance:%3x}", 14, "unknown format \"%3x\""},
+		"invalid offset":    {"vm-{instance+one}", 13, "invalid offset \"+one\""},
+		"hash length":       {"st{basename}{hash}", 13, "hash placeholder requires a length"},
+		"hash range":        {"st{basename}{hash:40}", 19, "hash length \"40\" must be a number between 1 and 32"},
+		"hash component":    {"st{hash:6:basename,}", 20, "invalid hash component \"\""},
+		"hash arguments":    {"st{hash:6:basename:x}", 20, "unexpected argument \"x\""},
+		"hash separator":    {"st{hash:6:sep}", 11, "invalid hash component \"sep\""},
+		"separator format":  {"rg{sep:short}", 7, "doesn't accept a format or an offset"},
+		"separator offset":  {"rg{sep+1}", 7, "doesn't accept a format or an offset"},
+		"reference filters": {"rg-{@suffix|upper}", 5, "invalid pattern reference \"@suffix|upper\""},
+		"empty reference":   {"snet-{@@}", 7, "invalid pattern reference \"@@\""},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseNamingPattern(testCase.pattern)
+			var syntaxErr *patternSyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a patternSyntaxError, got %v", err)
+			}
+			if syntaxErr.Column != testCase.column {
+				t.Errorf("expected column %d, got %d (%s)", testCase.column, syntaxErr.Column, syntaxErr)
+			}
+			if !strings.Contains(syntaxErr.Message, testCase.message) {
+				t.Errorf("expected message containing %q, got %q", testCase.message, syntaxErr.Message)
+			}
+		})
+	}
+}
+
+func TestNamingPatternRender(t *testing.T) {
+	parsed, err := parseNamingPattern("{a}-{b}-{c}")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	values := map[string]string{"a": "{b}", "b": "two"}
+	result, unresolved, err := parsed.render(func(node patternNode) (string, bool, error) {
+		value, ok := values[node.Name]
+		return value, ok, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Values are inserted as literals and never expanded again
+	if result != "{b}-two-" {
+		t.Errorf("expected %q, got %q", "{b}-two-", result)
+	}
+	if len(unresolved) != 1 || unresolved[0].Text != "{c}" || unresolved[0].Column != 9 {
+		t.Errorf("expected {c} at column 9 to be unresolved, got %#v", unresolved)
+	}
+}
+
+func TestFindPatternMetacharacter(t *testing.T) {
+	testCases := map[string]string{
+		"westeurope":    "",
+		"{region}":      "{",
+		"web}":          "}",
+		"app[-{i}]":     "[",
+		"a]":            "]",
+		"Zürich-(prod)": "",
+	}
+
+	for value, expected := range testCases {
+		t.Run(value, func(t *testing.T) {
+			if result := findPatternMetacharacter(value); result != expected {
+				t.Errorf("expected %q, got %q", expected, result)
+			}
+		})
+	}
+}
+
+func TestNamingPatternRender_OptionalSegments(t *testing.T) {
+	values := map[string]string{"basename": "app", "region": "we", "env": "prd"}
+	testCases := map[string]string{
+		"rg-{basename}[-{instance}]-{region}":          "rg-app-we",
+		"rg-{basename}-[{instance}]-{region}":          "rg-app-we",
+		"rg-{basename}-[{instance}]-[{slot}]-{region}": "rg-app-we",
+		"[{instance}-]rg-{basename}":                   "rg-app",
+		"rg-{basename}-[{instance}]":                   "rg-app",
+		"rg-{basename}[-{env}]-{region}":               "rg-app-prd-we",
+		"rg-[[{basename}]]":                            "rg-[app]",
+		"rg_{basename}_[{instance}]_{region}":          "rg_app_we",
+	}
+
+	for pattern, expected := range testCases {
+		t.Run(pattern, func(t *testing.T) {
+			parsed, err := parseNamingPattern(pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			result, unresolved, err := parsed.render(func(node patternNode) (string, bool, error) {
+				value, ok := values[node.Name]
+				return value, ok, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(unresolved) > 0 {
+				t.Fatalf("unexpected unresolved placeholders: %s", describePlaceholders(unresolved))
+			}
+			if result != expected {
+				t.Errorf("expected %q, got %q", expected, result)
+			}
+		})
+	}
+}
+
+func TestNamingPatternRender_EmptyValues(t *testing.T) {
+	values := map[string]string{"basename": "my-app", "region": "we", "empty": ""}
+	testCases := map[string]string{
+		"rg-{basename}-{empty}-{region}":         "rg-my-app-we",
+		"rg-{basename}-{empty}-{empty}-{region}": "rg-my-app-we",
+		"{empty}-rg-{basename}":                  "rg-my-app",
+		"rg-{basename}-{empty}":                  "rg-my-app",
+		"rg-{basename}[-{empty}-{region}]":       "rg-my-app-we",
+		"rg--{basename}":                         "rg--my-app",
+	}
+
+	for pattern, expected := range testCases {
+		t.Run(pattern, func(t *testing.T) {
+			parsed, err := parseNamingPattern(pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			result, _, err := parsed.render(func(node patternNode) (string, bool, error) {
+				value, ok := values[node.Name]
+				return value, ok, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if result != expected {
+				t.Errorf("expected %q, got %q", expected, result)
+			}
+		})
+	}
+}
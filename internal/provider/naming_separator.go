@@ -0,0 +1,54 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+)
+
+// separatorPlaceholderName is the name of the placeholder that is replaced by the separator of the resource
+// type, e.g. "rg{sep}{basename}{sep}{environment:short}"
+const separatorPlaceholderName = "sep"
+
+// defaultSeparator is used when neither the function call, the naming rules of the resource type nor the
+// provider configure a separator
+const defaultSeparator = "-"
+
+// separatorOption is the function option that overrides the separator for a single call
+const separatorOption = "separator"
+
+// isSeparatorPlaceholder reports whether node is a {sep} placeholder
+func isSeparatorPlaceholder(node patternNode) bool {
+	return node.Kind == placeholderNode && node.Name == separatorPlaceholderName
+}
+
+// isComponentPlaceholder reports whether node references a component, as opposed to the {hash:N} and {sep}
+// placeholders whose values are derived from the configuration
+func isComponentPlaceholder(node patternNode) bool {
+	return node.Kind == placeholderNode && !isHashPlaceholder(node) && !isSeparatorPlaceholder(node)
+}
+
+// lookupSeparator returns the value of the {sep} placeholder. The separator passed in the function call takes
+// precedence over the separator of the naming rules of the resource type, which takes precedence over the
+// default_separator of the provider. An empty separator is a valid value, e.g. for storage accounts.
+func lookupSeparator(ctx context.Context, config resourcenamingtoolProviderModel, constraint namingConstraint, options map[string]string) string {
+	if separator, ok := options[separatorOption]; ok {
+		logDebugWithFields(ctx, "Using separator from function options", map[string]interface{}{
+			"separator": separator,
+		})
+		return separator
+	}
+	if constraint.Separator != nil {
+		logDebugWithFields(ctx, "Using separator from naming rules", map[string]interface{}{
+			"separator": *constraint.Separator,
+		})
+		return *constraint.Separator
+	}
+	if !config.DefaultSeparator.IsNull() && !config.DefaultSeparator.IsUnknown() {
+		logDebugWithFields(ctx, "Using default separator from provider", map[string]interface{}{
+			"separator": config.DefaultSeparator.ValueString(),
+		})
+		return config.DefaultSeparator.ValueString()
+	}
+	return defaultSeparator
+}
@@ -0,0 +1,429 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// shorteningStrategy configures how a name that exceeds the max_length of its resource type is shortened
+type shorteningStrategy struct {
+	// Steps are applied in order until the name fits, defaults to defaultShorteningSteps
+	Steps []string `json:"steps,omitempty"`
+	// ComponentOrder lists the components in the order they are abbreviated, defaults to the
+	// components of the pattern from right to left
+	ComponentOrder []string `json:"component_order,omitempty"`
+	// HashLength is the number of characters of the hash appended by the truncate step, nil uses
+	// defaultShorteningHashLength
+	HashLength *int `json:"hash_length,omitempty"`
+}
+
+// shorteningModel is the Terraform representation of a shortening strategy
+type shorteningModel struct {
+	Steps          types.List  `tfsdk:"steps"`
+	ComponentOrder types.List  `tfsdk:"component_order"`
+	HashLength     types.Int64 `tfsdk:"hash_length"`
+}
+
+// shorteningAttributeTypes are the attribute types of a shortening strategy
+var shorteningAttributeTypes = map[string]attr.Type{
+	"steps":           types.ListType{ElemType: types.StringType},
+	"component_order": types.ListType{ElemType: types.StringType},
+	"hash_length":     types.Int64Type,
+}
+
+// Supported shortening steps
+const (
+	// shorteningStepAbbreviate switches components from their fullname to their shortcode, and
+	// then from their shortcode to their char, one component at a time
+	shorteningStepAbbreviate = "abbreviate"
+	// shorteningStepRemoveSeparators removes the separators from the literal text of the pattern
+	shorteningStepRemoveSeparators = "remove_separators"
+	// shorteningStepTruncate truncates the name and appends a hash of the complete name
+	shorteningStepTruncate = "truncate"
+)
+
+var (
+	// defaultShorteningSteps are applied when a strategy doesn't list its steps
+	defaultShorteningSteps = []string{shorteningStepAbbreviate, shorteningStepRemoveSeparators, shorteningStepTruncate}
+
+	// defaultShorteningHashLength is the length of the hash appended by the truncate step
+	defaultShorteningHashLength = 4
+
+	// maxShorteningHashLength is the longest hash the truncate step can append
+	maxShorteningHashLength = 16
+)
+
+// steps returns the steps of the strategy, or the default steps when none are configured
+func (s shorteningStrategy) steps() []string {
+	if len(s.Steps) == 0 {
+		return defaultShorteningSteps
+	}
+	return s.Steps
+}
+
+// hashLength returns the length of the hash appended by the truncate step
+func (s shorteningStrategy) hashLength() int {
+	if s.HashLength == nil {
+		return defaultShorteningHashLength
+	}
+	return *s.HashLength
+}
+
+// truncates reports whether the strategy contains the truncate step
+func (s shorteningStrategy) truncates() bool {
+	for _, step := range s.steps() {
+		if step == shorteningStepTruncate {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks that the strategy only contains supported steps and settings
+func (s shorteningStrategy) validate() error {
+	seen := make(map[string]bool)
+	for _, step := range s.Steps {
+		switch step {
+		case shorteningStepAbbreviate, shorteningStepRemoveSeparators, shorteningStepTruncate:
+		default:
+			return fmt.Errorf("step %q is not supported, expected %s, %s or %s", step, shorteningStepAbbreviate, shorteningStepRemoveSeparators, shorteningStepTruncate)
+		}
+		if seen[step] {
+			return fmt.Errorf("step %q is listed more than once", step)
+		}
+		seen[step] = true
+	}
+	for _, component := range s.ComponentOrder {
+		if !isValidPlaceholderName(component) {
+			return fmt.Errorf("component_order contains invalid component name %q", component)
+		}
+	}
+	if s.HashLength != nil && (*s.HashLength < 1 || *s.HashLength > maxShorteningHashLength) {
+		return fmt.Errorf("hash_length %d must be between 1 and %d", *s.HashLength, maxShorteningHashLength)
+	}
+	return nil
+}
+
+// shorteningRenderer renders a (shortened) pattern. It returns false when the pattern no longer
+// resolves all components that the original pattern resolved, in which case the candidate is discarded.
+type shorteningRenderer func(pattern *namingPattern) (string, bool, error)
+
+// shortenName applies the steps of the strategy, in order, until the rendered name no longer exceeds the
+// max_length of the constraint. It returns the shortened name and the steps that were applied. When the name
+// cannot be shortened enough, the shortest name that was produced is returned.
+func shortenName(pattern *namingPattern, name string, strategy shorteningStrategy, constraint namingConstraint, render shorteningRenderer) (string, []string, error) {
+	var applied []string
+	fits := func(candidate string) bool {
+		return constraint.length(candidate) <= constraint.MaxLength
+	}
+	if constraint.MaxLength <= 0 || fits(name) {
+		return name, applied, nil
+	}
+
+	original := name
+	current := pattern
+	for _, step := range strategy.steps() {
+		switch step {
+		case shorteningStepAbbreviate:
+			abbreviated := false
+			for _, transition := range [][2]string{{"full", "short"}, {"short", "char"}} {
+				for _, component := range strategy.componentOrder(current) {
+					candidate, changed := abbreviateComponent(current, component, transition[0], transition[1])
+					if !changed {
+						continue
+					}
+					candidateName, ok, err := render(candidate)
+					if err != nil {
+						return "", applied, err
+					}
+					if !ok {
+						continue
+					}
+					current, name, abbreviated = candidate, candidateName, true
+					if fits(name) {
+						return name, append(applied, step), nil
+					}
+				}
+			}
+			// The step is only reported when at least one component was abbreviated
+			if !abbreviated {
+				continue
+			}
+		case shorteningStepRemoveSeparators:
+			candidate := removeLiteralSeparators(current)
+			candidateName, ok, err := render(candidate)
+			if err != nil {
+				return "", applied, err
+			}
+			if !ok {
+				continue
+			}
+			current, name = candidate, candidateName
+		case shorteningStepTruncate:
+			name = truncateWithHash(name, original, constraint, strategy.hashLength())
+		}
+
+		applied = append(applied, step)
+		if fits(name) {
+			return name, applied, nil
+		}
+	}
+
+	return name, applied, nil
+}
+
+// componentOrder returns the components in the order they are abbreviated
+func (s shorteningStrategy) componentOrder(pattern *namingPattern) []string {
+	if len(s.ComponentOrder) > 0 {
+		order := make([]string, 0, len(s.ComponentOrder))
+		for _, name := range s.ComponentOrder {
+			order = append(order, canonicalComponentName(name))
+		}
+		return order
+	}
+
+	// By default the components are abbreviated from right to left, so the leading components,
+	// which usually identify the resource, keep their full value the longest
+	placeholders := pattern.placeholders()
+	seen := make(map[string]bool)
+	order := make([]string, 0, len(placeholders))
+	for i := len(placeholders) - 1; i >= 0; i-- {
+		if !isComponentPlaceholder(placeholders[i]) {
+			continue
+		}
+		component := canonicalComponentName(placeholders[i].Name)
+		if !seen[component] {
+			seen[component] = true
+			order = append(order, component)
+		}
+	}
+	return order
+}
+
+// canonicalComponentName returns the component name for a placeholder name or alias
+func canonicalComponentName(name string) string {
+	if component, _, ok := lookupComponentPlaceholder(name); ok {
+		return component
+	}
+	return name
+}
+
+// placeholderFormat returns the representation used by a placeholder node
+func placeholderFormat(node patternNode) string {
+	if len(node.Args) > 0 {
+		return node.Args[0]
+	}
+	if _, format, ok := lookupComponentPlaceholder(node.Name); ok {
+		return format
+	}
+	return "full"
+}
+
+// abbreviateComponent returns a copy of the pattern in which the placeholders of component that use
+// the from representation use the to representation instead
+func abbreviateComponent(pattern *namingPattern, component, from, to string) (*namingPattern, bool) {
+	var changed bool
+	var rewrite func(nodes []patternNode) []patternNode
+	rewrite = func(nodes []patternNode) []patternNode {
+		result := make([]patternNode, len(nodes))
+		for i, node := range nodes {
+			switch node.Kind {
+			case placeholderNode:
+				if canonicalComponentName(node.Name) == component && placeholderFormat(node) == from {
+					node.Args = []string{to}
+					changed = true
+				}
+			case optionalNode:
+				node.Children = rewrite(node.Children)
+			}
+			result[i] = node
+		}
+		return result
+	}
+
+	nodes := rewrite(pattern.Nodes)
+	return &namingPattern{Source: pattern.Source, Nodes: nodes}, changed
+}
+
+// removeLiteralSeparators returns a copy of the pattern without separators in its literal text and
+// without {sep} placeholders, component values are left untouched
+func removeLiteralSeparators(pattern *namingPattern) *namingPattern {
+	var rewrite func(nodes []patternNode) []patternNode
+	rewrite = func(nodes []patternNode) []patternNode {
+		result := make([]patternNode, 0, len(nodes))
+		for _, node := range nodes {
+			switch node.Kind {
+			case literalNode:
+				node.Text = strings.Map(func(r rune) rune {
+					if r < utf8.RuneSelf && isPatternSeparator(byte(r)) {
+						return -1
+					}
+					return r
+				}, node.Text)
+			case placeholderNode:
+				if isSeparatorPlaceholder(node) {
+					continue
+				}
+			case optionalNode:
+				node.Children = rewrite(node.Children)
+			}
+			result = append(result, node)
+		}
+		return result
+	}
+
+	return &namingPattern{Source: pattern.Source, Nodes: rewrite(pattern.Nodes)}
+}
+
+// truncateWithHash truncates name so that, together with a hash of the original name, it fits the
+// max_length of the constraint. The hash keeps truncated names unique and stable across runs.
+func truncateWithHash(name, original string, constraint namingConstraint, hashLength int) string {
+	if hashLength > constraint.MaxLength {
+		hashLength = constraint.MaxLength
+	}
+	// Keep at least the first character of the name, a name that only consists of the hash could start
+	// with a digit and break the leading character rule of the resource type
+	runes := []rune(name)
+	if len(runes) > 0 {
+		first := constraint.length(string(runes[:1]))
+		if first < constraint.MaxLength && hashLength > constraint.MaxLength-first {
+			hashLength = constraint.MaxLength - first
+		}
+	}
+	hash := shortHash(original, hashLength)
+	if constraint.Case == namingCaseUpper {
+		hash = strings.ToUpper(hash)
+	}
+
+	// The hash is ASCII, so it has the same length in every length unit. Whole characters are removed until the
+	// rest of the name fits, multi-byte characters are never split.
+	keep := min(constraint.MaxLength-hashLength, len(runes))
+	for keep > 0 && constraint.length(string(runes[:keep])) > constraint.MaxLength-hashLength {
+		keep--
+	}
+	// Don't leave a separator between the truncated name and the hash, unless it is all that is left of the name
+	prefix := string(runes[:keep])
+	if trimmed := strings.TrimRight(prefix, patternSeparators); trimmed != "" {
+		prefix = trimmed
+	}
+	return prefix + hash
+}
+
+// lookupShorteningStrategy returns the shortening strategy for a resource type, the strategy in the
+// naming rules of the resource type takes precedence over the provider wide strategy
+func lookupShorteningStrategy(ctx context.Context, config resourcenamingtoolProviderModel, constraint namingConstraint) *shorteningStrategy {
+	if constraint.Shortening != nil {
+		return constraint.Shortening
+	}
+	strategy, diags := shorteningFromObject(ctx, config.Shortening)
+	if diags.HasError() {
+		logErrorWithFields(ctx, "Failed to read shortening strategy from provider config", map[string]interface{}{
+			"error": diags.Errors()[0].Summary(),
+		})
+		return nil
+	}
+	return strategy
+}
+
+// shorteningFromObject converts the Terraform representation of a shortening strategy, it returns nil
+// when no strategy is configured
+func shorteningFromObject(ctx context.Context, object types.Object) (*shorteningStrategy, diag.Diagnostics) {
+	if object.IsNull() || object.IsUnknown() {
+		return nil, nil
+	}
+
+	var model shorteningModel
+	diags := object.As(ctx, &model, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	strategy := &shorteningStrategy{HashLength: optionalInt(model.HashLength)}
+	if !model.Steps.IsNull() && !model.Steps.IsUnknown() {
+		diags.Append(model.Steps.ElementsAs(ctx, &strategy.Steps, false)...)
+	}
+	if !model.ComponentOrder.IsNull() && !model.ComponentOrder.IsUnknown() {
+		diags.Append(model.ComponentOrder.ElementsAs(ctx, &strategy.ComponentOrder, false)...)
+	}
+	return strategy, diags
+}
+
+// shorteningToObject converts a shortening strategy into its Terraform representation
+func shorteningToObject(ctx context.Context, strategy *shorteningStrategy) (types.Object, diag.Diagnostics) {
+	if strategy == nil {
+		return types.ObjectNull(shorteningAttributeTypes), nil
+	}
+
+	var diags diag.Diagnostics
+	model := shorteningModel{
+		Steps:          types.ListNull(types.StringType),
+		ComponentOrder: types.ListNull(types.StringType),
+		HashLength:     optionalIntValue(strategy.HashLength),
+	}
+	if len(strategy.Steps) > 0 {
+		steps, stepDiags := types.ListValueFrom(ctx, types.StringType, strategy.Steps)
+		diags.Append(stepDiags...)
+		model.Steps = steps
+	}
+	if len(strategy.ComponentOrder) > 0 {
+		order, orderDiags := types.ListValueFrom(ctx, types.StringType, strategy.ComponentOrder)
+		diags.Append(orderDiags...)
+		model.ComponentOrder = order
+	}
+	object, objectDiags := types.ObjectValueFrom(ctx, shorteningAttributeTypes, model)
+	diags.Append(objectDiags...)
+	return object, diags
+}
+
+// shorteningFromJSON converts the JSON representation of a shortening strategy, as saved in the
+// configuration file, into its Terraform representation
+func shorteningFromJSON(ctx context.Context, raw interface{}) (types.Object, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return types.ObjectNull(shorteningAttributeTypes), err
+	}
+	var strategy shorteningStrategy
+	if err := json.Unmarshal(data, &strategy); err != nil {
+		return types.ObjectNull(shorteningAttributeTypes), err
+	}
+	object, diags := shorteningToObject(ctx, &strategy)
+	if diags.HasError() {
+		return types.ObjectNull(shorteningAttributeTypes), fmt.Errorf("%s", diags.Errors()[0].Summary())
+	}
+	return object, nil
+}
+
+// shorteningSchemaAttribute returns the schema of a shortening strategy
+func shorteningSchemaAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"steps": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Shortening steps applied in order until the name fits: 'abbreviate' switches components from fullname to shortcode to char, 'remove_separators' removes the separators between components and 'truncate' truncates the name and appends a hash of the complete name. Defaults to all steps in this order.",
+			},
+			"component_order": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Components in the order they are abbreviated by the 'abbreviate' step (e.g., ['instance', 'region', 'environment']). Components that are not listed keep their representation. Defaults to all components of the pattern from right to left.",
+			},
+			"hash_length": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of characters of the hash appended by the 'truncate' step, between 1 and 16. Defaults to 4.",
+			},
+		},
+	}
+}
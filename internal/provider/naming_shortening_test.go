@@ -0,0 +1,197 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+// testShorteningRenderer renders patterns using fixed component values per representation
+func testShorteningRenderer(t *testing.T) shorteningRenderer {
+	t.Helper()
+	values := map[string]map[string]string{
+		"basename":    {"full": "contosowebapplication", "short": "cwa", "char": "c"},
+		"environment": {"full": "production", "short": "prd", "char": "p"},
+		"region":      {"full": "westeurope", "short": "weu", "char": "w"},
+		"department":  {"full": "engineering"},
+		"project":     {"full": "zürichgroß"},
+	}
+	return func(pattern *namingPattern) (string, bool, error) {
+		name, unresolved, err := pattern.render(func(node patternNode) (string, bool, error) {
+			if isSeparatorPlaceholder(node) {
+				return "-", true, nil
+			}
+			value, ok := values[canonicalComponentName(node.Name)][placeholderFormat(node)]
+			return value, ok, nil
+		})
+		return name, len(unresolved) == 0, err
+	}
+}
+
+func TestShortenName(t *testing.T) {
+	testCases := map[string]struct {
+		pattern    string
+		strategy   shorteningStrategy
+		constraint namingConstraint
+		expected   string
+		steps      []string
+	}{
+		"fits": {
+			pattern:    "st{basename:short}{region:short}",
+			constraint: namingConstraint{MaxLength: 24},
+			expected:   "stcwaweu",
+		},
+		"abbreviate right to left": {
+			pattern:    "st{basename}{environment}{region}",
+			constraint: namingConstraint{MaxLength: 24},
+			expected:   "stcwaprdweu",
+			steps:      []string{shorteningStepAbbreviate},
+		},
+		"abbreviate stops when the name fits": {
+			pattern:    "kv-{basename}-{environment}-{region}",
+			constraint: namingConstraint{MaxLength: 36},
+			expected:   "kv-contosowebapplication-prd-weu",
+			steps:      []string{shorteningStepAbbreviate},
+		},
+		"abbreviate to char": {
+			pattern:    "kv-{basename:short}-{environment}-{region}",
+			strategy:   shorteningStrategy{ComponentOrder: []string{"r", "env"}},
+			constraint: namingConstraint{MaxLength: 11},
+			expected:   "kv-cwa-p-w",
+			steps:      []string{shorteningStepAbbreviate},
+		},
+		"components without a shortcode keep their fullname": {
+			pattern:    "{department}-{region}",
+			constraint: namingConstraint{MaxLength: 13},
+			expected:   "engineering-w",
+			steps:      []string{shorteningStepAbbreviate},
+		},
+		"remove separators": {
+			pattern:    "kv-{basename}-{environment}",
+			strategy:   shorteningStrategy{Steps: []string{shorteningStepRemoveSeparators}},
+			constraint: namingConstraint{MaxLength: 33},
+			expected:   "kvcontosowebapplicationproduction",
+			steps:      []string{shorteningStepRemoveSeparators},
+		},
+		"remove separator placeholders": {
+			pattern:    "kv{sep}{basename}{sep}{environment}",
+			strategy:   shorteningStrategy{Steps: []string{shorteningStepRemoveSeparators}},
+			constraint: namingConstraint{MaxLength: 33},
+			expected:   "kvcontosowebapplicationproduction",
+			steps:      []string{shorteningStepRemoveSeparators},
+		},
+		"truncate": {
+			pattern:    "kv-{basename}-{environment}",
+			strategy:   shorteningStrategy{Steps: []string{shorteningStepTruncate}},
+			constraint: namingConstraint{MaxLength: 24},
+			expected:   "kv-contosowebapplica" + shortHash("kv-contosowebapplication-production", 4),
+			steps:      []string{shorteningStepTruncate},
+		},
+		"truncate without trailing separator": {
+			pattern:    "kv-{basename}-{environment}",
+			strategy:   shorteningStrategy{Steps: []string{shorteningStepTruncate}, HashLength: intPointer(6)},
+			constraint: namingConstraint{MaxLength: 31, Case: namingCaseUpper},
+			expected:   "kv-contosowebapplication" + strings.ToUpper(shortHash("kv-contosowebapplication-production", 6)),
+			steps:      []string{shorteningStepTruncate},
+		},
+		"truncate multi-byte characters": {
+			pattern:    "{project}-{environment}",
+			strategy:   shorteningStrategy{Steps: []string{shorteningStepTruncate}},
+			constraint: namingConstraint{MaxLength: 10, LengthUnit: namingLengthBytes},
+			expected:   "züric" + shortHash("zürichgroß-production", 4),
+			steps:      []string{shorteningStepTruncate},
+		},
+		"truncate keeps the first character": {
+			pattern:    "{basename}-{environment}",
+			strategy:   shorteningStrategy{Steps: []string{shorteningStepTruncate}, HashLength: intPointer(16)},
+			constraint: namingConstraint{MaxLength: 8},
+			expected:   "c" + shortHash("contosowebapplication-production", 7),
+			steps:      []string{shorteningStepTruncate},
+		},
+		"abbreviate is not reported when no component can be abbreviated": {
+			pattern:    "{department}-data",
+			strategy:   shorteningStrategy{Steps: []string{shorteningStepAbbreviate, shorteningStepTruncate}},
+			constraint: namingConstraint{MaxLength: 10},
+			expected:   "engine" + shortHash("engineering-data", 4),
+			steps:      []string{shorteningStepTruncate},
+		},
+		"all steps": {
+			pattern:    "st-{basename}-{environment}-{region}",
+			strategy:   shorteningStrategy{ComponentOrder: []string{"environment", "region"}},
+			constraint: namingConstraint{MaxLength: 24},
+			expected:   "stcontosowebapplicat" + shortHash("st-contosowebapplication-production-westeurope", 4),
+			steps:      []string{shorteningStepAbbreviate, shorteningStepRemoveSeparators, shorteningStepTruncate},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseNamingPattern(testCase.pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			render := testShorteningRenderer(t)
+			original, _, err := render(parsed)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			result, steps, err := shortenName(parsed, original, testCase.strategy, testCase.constraint, render)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+			if strings.Join(steps, ",") != strings.Join(testCase.steps, ",") {
+				t.Errorf("expected steps %v, got %v", testCase.steps, steps)
+			}
+		})
+	}
+}
+
+func TestShortHash(t *testing.T) {
+	first := shortHash("stcontosowebapplicationproductionwesteurope", 6)
+	if len(first) != 6 {
+		t.Fatalf("expected a hash of 6 characters, got %q", first)
+	}
+	if strings.Trim(first, "abcdefghijklmnopqrstuvwxyz0123456789") != "" {
+		t.Errorf("expected a lower case base36 hash, got %q", first)
+	}
+	if second := shortHash("stcontosowebapplicationproductionwesteurope", 6); second != first {
+		t.Errorf("expected a stable hash, got %q and %q", first, second)
+	}
+	if other := shortHash("stcontosowebapplicationproductionnortheurope", 6); other == first {
+		t.Errorf("expected different names to produce different hashes, both produced %q", first)
+	}
+}
+
+func TestShorteningStrategyValidate(t *testing.T) {
+	testCases := map[string]struct {
+		strategy shorteningStrategy
+		message  string
+	}{
+		"valid":              {shorteningStrategy{Steps: []string{shorteningStepAbbreviate, shorteningStepTruncate}, HashLength: intPointer(6)}, ""},
+		"unsupported step":   {shorteningStrategy{Steps: []string{"compress"}}, "step \"compress\" is not supported"},
+		"duplicate step":     {shorteningStrategy{Steps: []string{shorteningStepTruncate, shorteningStepTruncate}}, "listed more than once"},
+		"invalid component":  {shorteningStrategy{ComponentOrder: []string{"{region}"}}, "invalid component name"},
+		"hash length bounds": {shorteningStrategy{HashLength: intPointer(40)}, "hash_length 40 must be between 1 and 16"},
+		"zero hash length":   {shorteningStrategy{HashLength: intPointer(0)}, "hash_length 0 must be between 1 and 16"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.strategy.validate()
+			if testCase.message == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), testCase.message) {
+				t.Errorf("expected error containing %q, got %v", testCase.message, err)
+			}
+		})
+	}
+}
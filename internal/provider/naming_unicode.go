@@ -0,0 +1,106 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// asciiTransliterations maps the accented and special Latin letters to their ASCII transliteration
+var asciiTransliterations = map[rune]string{
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Æ': "AE", 'Ç': "C",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I",
+	'Ð': "D", 'Ñ': "N", 'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ý': "Y", 'Þ': "TH", 'ß': "ss", 'à': "a",
+	'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae", 'ç': "c", 'è': "e",
+	'é': "e", 'ê': "e", 'ë': "e", 'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ð': "d",
+	'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ù': "u",
+	'ú': "u", 'û': "u", 'ü': "u", 'ý': "y", 'þ': "th", 'ÿ': "y", 'Ā': "A", 'ā': "a",
+	'Ă': "A", 'ă': "a", 'Ą': "A", 'ą': "a", 'Ć': "C", 'ć': "c", 'Ĉ': "C", 'ĉ': "c",
+	'Ċ': "C", 'ċ': "c", 'Č': "C", 'č': "c", 'Ď': "D", 'ď': "d", 'Đ': "D", 'đ': "d",
+	'Ē': "E", 'ē': "e", 'Ĕ': "E", 'ĕ': "e", 'Ė': "E", 'ė': "e", 'Ę': "E", 'ę': "e",
+	'Ě': "E", 'ě': "e", 'Ĝ': "G", 'ĝ': "g", 'Ğ': "G", 'ğ': "g", 'Ġ': "G", 'ġ': "g",
+	'Ģ': "G", 'ģ': "g", 'Ĥ': "H", 'ĥ': "h", 'Ħ': "H", 'ħ': "h", 'Ĩ': "I", 'ĩ': "i",
+	'Ī': "I", 'ī': "i", 'Ĭ': "I", 'ĭ': "i", 'Į': "I", 'į': "i", 'İ': "I", 'ı': "i",
+	'Ĳ': "IJ", 'ĳ': "ij", 'Ĵ': "J", 'ĵ': "j", 'Ķ': "K", 'ķ': "k", 'ĸ': "k", 'Ĺ': "L",
+	'ĺ': "l", 'Ļ': "L", 'ļ': "l", 'Ľ': "L", 'ľ': "l", 'Ŀ': "L", 'ŀ': "l", 'Ł': "L",
+	'ł': "l", 'Ń': "N", 'ń': "n", 'Ņ': "N", 'ņ': "n", 'Ň': "N", 'ň': "n", 'Ŋ': "N",
+	'ŋ': "n", 'Ō': "O", 'ō': "o", 'Ŏ': "O", 'ŏ': "o", 'Ő': "O", 'ő': "o", 'Œ': "OE",
+	'œ': "oe", 'Ŕ': "R", 'ŕ': "r", 'Ŗ': "R", 'ŗ': "r", 'Ř': "R", 'ř': "r", 'Ś': "S",
+	'ś': "s", 'Ŝ': "S", 'ŝ': "s", 'Ş': "S", 'ş': "s", 'Š': "S", 'š': "s", 'Ţ': "T",
+	'ţ': "t", 'Ť': "T", 'ť': "t", 'Ŧ': "T", 'ŧ': "t", 'Ũ': "U", 'ũ': "u", 'Ū': "U",
+	'ū': "u", 'Ŭ': "U", 'ŭ': "u", 'Ů': "U", 'ů': "u", 'Ű': "U", 'ű': "u", 'Ų': "U",
+	'ų': "u", 'Ŵ': "W", 'ŵ': "w", 'Ŷ': "Y", 'ŷ': "y", 'Ÿ': "Y", 'Ź': "Z", 'ź': "z",
+	'Ż': "Z", 'ż': "z", 'Ž': "Z", 'ž': "z", 'ſ': "s", 'ẞ': "SS",
+}
+
+// transliterateASCII replaces the Latin letters of value that have an ASCII transliteration, e.g. "Zürich" becomes
+// "Zurich" and "Ørsted" becomes "Orsted". Other characters are kept, so that the naming rules can report them.
+func transliterateASCII(value string) string {
+	var result strings.Builder
+	result.Grow(len(value))
+	for _, r := range value {
+		if replacement, ok := asciiTransliterations[r]; ok {
+			result.WriteString(replacement)
+			continue
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
+
+// runePrefix returns the first n characters of value without splitting multi-byte characters
+func runePrefix(value string, n int) string {
+	if utf8.RuneCountInString(value) <= n {
+		return value
+	}
+	return string([]rune(value)[:n])
+}
+
+// Supported values for the length_unit rule, cloud providers count the length of names differently
+const (
+	// namingLengthCharacters counts Unicode characters
+	namingLengthCharacters = "characters"
+	// namingLengthBytes counts the bytes of the UTF-8 encoding, e.g. AWS validates names as byte strings
+	namingLengthBytes = "bytes"
+	// namingLengthUTF16 counts UTF-16 code units, e.g. Azure Resource Manager validates names as .NET strings
+	namingLengthUTF16 = "utf16"
+)
+
+// defaultLengthUnit returns how the cloud provider of a resource type counts the length of names
+func defaultLengthUnit(resourceType string) string {
+	switch {
+	case strings.HasPrefix(resourceType, "azurerm_"), strings.HasPrefix(resourceType, "azuread_"):
+		return namingLengthUTF16
+	case strings.HasPrefix(resourceType, "aws_"):
+		return namingLengthBytes
+	default:
+		return namingLengthCharacters
+	}
+}
+
+// nameLength returns the length of name in the given unit
+func nameLength(name, unit string) int {
+	switch unit {
+	case namingLengthBytes:
+		return len(name)
+	case namingLengthUTF16:
+		return len(utf16.Encode([]rune(name)))
+	default:
+		return utf8.RuneCountInString(name)
+	}
+}
+
+// lengthUnitDescription returns the unit used in messages about the length of a name
+func lengthUnitDescription(unit string) string {
+	switch unit {
+	case namingLengthBytes:
+		return "bytes"
+	case namingLengthUTF16:
+		return "UTF-16 code units"
+	default:
+		return "characters"
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestTransliterateASCII(t *testing.T) {
+	testCases := map[string]string{
+		"Zürich":     "Zurich",
+		"Ørsted":     "Orsted",
+		"Straße":     "Strasse",
+		"Łódź":       "Lodz",
+		"Æbeltoft":   "AEbeltoft",
+		"ascii-only": "ascii-only",
+		"東京-01":      "東京-01",
+	}
+
+	for value, expected := range testCases {
+		t.Run(value, func(t *testing.T) {
+			if result := transliterateASCII(value); result != expected {
+				t.Errorf("expected %q, got %q", expected, result)
+			}
+		})
+	}
+}
+
+func TestRunePrefix(t *testing.T) {
+	testCases := map[string]struct {
+		value    string
+		n        int
+		expected string
+	}{
+		"ascii":          {"westeurope", 3, "wes"},
+		"multi-byte":     {"Zürich", 3, "Zür"},
+		"first rune":     {"Ørsted", 1, "Ø"},
+		"shorter than n": {"ab", 3, "ab"},
+		"zero":           {"Zürich", 0, ""},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if result := runePrefix(testCase.value, testCase.n); result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestNameLength(t *testing.T) {
+	testCases := map[string]struct {
+		name     string
+		unit     string
+		expected int
+	}{
+		"ascii characters":  {"example", namingLengthCharacters, 7},
+		"ascii bytes":       {"example", namingLengthBytes, 7},
+		"umlaut characters": {"Zürich", namingLengthCharacters, 6},
+		"umlaut bytes":      {"Zürich", namingLengthBytes, 7},
+		"umlaut utf16":      {"Zürich", namingLengthUTF16, 6},
+		"emoji characters":  {"app-🚀", namingLengthCharacters, 5},
+		"emoji bytes":       {"app-🚀", namingLengthBytes, 8},
+		"emoji utf16":       {"app-🚀", namingLengthUTF16, 6},
+		"default unit":      {"Zürich", "", 6},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if result := nameLength(testCase.name, testCase.unit); result != testCase.expected {
+				t.Errorf("expected %d, got %d", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestDefaultLengthUnit(t *testing.T) {
+	testCases := map[string]string{
+		"azurerm_storage_account": namingLengthUTF16,
+		"azuread_application":     namingLengthUTF16,
+		"aws_s3_bucket":           namingLengthBytes,
+		"google_storage_bucket":   namingLengthCharacters,
+	}
+
+	for resourceType, expected := range testCases {
+		t.Run(resourceType, func(t *testing.T) {
+			if result := defaultLengthUnit(resourceType); result != expected {
+				t.Errorf("expected %q, got %q", expected, result)
+			}
+		})
+	}
+}
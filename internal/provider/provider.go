@@ -60,119 +60,107 @@ func (p *resourcenamingtoolFunctionsProvider) Schema(_ context.Context, _ provid
 				Description: "A unique identifier for this provider instance. Used to avoid configuration file conflicts when using multiple provider instances in the same Terraform configuration.",
 			},
 
-			// Core components
-			"default_resource_type": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
-				Optional:    true,
-				Description: "Default resource type to use when not provided in the function call. This corresponds to the type of resource being created (e.g., 'virtual_machine', 'storage_account'). The resource type determines which naming pattern is used.",
-			},
-			"default_resource_prefix": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
-				Optional:    true,
-				Description: "Default resource prefix to use when not provided in the function call. This is an optional prefix that goes before the resource type abbreviation in the name pattern (e.g., 'shared', 'core').",
-			},
-			"default_basename": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
-				Optional:    true,
-				Description: "Default basename to use when not provided in the function call. This is the core identifying name of the resource that will be part of all resource names (e.g., 'webapp', 'payroll').",
-			},
-			"default_environment": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
-				Optional:    true,
-				Description: "Default environment to use when not provided in the function call. Represents the deployment environment (e.g., 'dev', 'test', 'prod'). Used to distinguish resources across different environments.",
-			},
-			"default_region": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
-				Optional:    true,
-				Description: "Default region to use when not provided in the function call. Represents the cloud region where the resource is deployed (e.g., 'eastus', 'westeurope', 'us-west-2'). Often used in naming patterns to distinguish resources across regions.",
-			},
-			"default_instance": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
-				Optional:    true,
-				Description: "Default instance identifier to use when not provided in the function call. Used to distinguish between multiple instances of the same resource type (e.g., '01', '02'). Commonly used for resources that are deployed in multiples.",
-			},
-
-			// Organization related components
-			"default_organization": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
-				Optional:    true,
-				Description: "Default organization to use when not provided in the function call. Identifies the overall organization owning the resource (e.g., 'contoso', 'fabrikam').",
-			},
-			"default_business_unit": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
-				Optional:    true,
-				Description: "Default business unit to use when not provided in the function call. Identifies the business unit within the organization (e.g., 'finance', 'hr', 'it').",
-			},
-			"default_cost_center": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
-				Optional:    true,
-				Description: "Default cost center to use when not provided in the function call. Identifies the financial cost center associated with the resource (e.g., 'cc123', 'marketing').",
-			},
-			"default_project": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
-				Optional:    true,
-				Description: "Default project to use when not provided in the function call. Identifies the project associated with the resource (e.g., 'website-redesign', 'data-migration').",
-			},
-			"default_application": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
+			// Extension points
+			"additional_components": schema.MapAttribute{
+				ElementType: NewComponentValueType(),
 				Optional:    true,
-				Description: "Default application name to use when not provided in the function call. Identifies the application using the resource (e.g., 'inventory-system', 'crm').",
+				Description: "Additional custom components to use in resource name generation. Keys must be wrapped in curly braces to be used in patterns (e.g., {custom_component1}, {department}). These can be used in custom naming patterns and are used when the function call doesn't provide the component, a missing shortcode or char is derived from the fullname.",
 			},
-			"default_workload": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
+			"builtin_pattern_sets": schema.ListAttribute{
+				ElementType: types.StringType,
 				Optional:    true,
-				Description: "Default workload type to use when not provided in the function call. Describes the function or purpose of the workload (e.g., 'api', 'web', 'batch').",
+				Description: "Built-in pattern sets providing the naming patterns of common resource types: 'azure_caf' (Microsoft Cloud Adoption Framework), 'aws_waf' (AWS Well-Architected Framework) and 'gcp' (Google Cloud). When several sets have a pattern for the same resource type, the set listed last wins. The additional_naming_patterns override individual patterns of the sets.",
 			},
-
-			// Provider specific components
-			"default_subscription": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
+			"additional_naming_patterns": schema.MapAttribute{
+				ElementType: types.StringType,
 				Optional:    true,
-				Description: "Default subscription to use when not provided in the function call. Primarily used with Azure to identify the subscription context (e.g., 'prod', 'dev', 'subscription-name').",
+				Description: "Additional naming patterns for specific resource types. Overrides built-in patterns. Keys should match resource types, wildcards of resource types (e.g., \"azurerm_*_database\", \"aws_*\") or \"default\" for all other resource types, the most specific key wins. Values should contain component placeholders (e.g., \"my_custom_resource\": \"prefix-{basename}-{environment:short}\").",
 			},
-			"default_location": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
+			"allowed_values": schema.MapAttribute{
+				ElementType: types.ListType{ElemType: types.StringType},
 				Optional:    true,
-				Description: "Default location to use when not provided in the function call. Alternative to region, used in some cloud providers (e.g., 'eastus', 'westeurope').",
+				Description: "Allowed values of components keyed by component name (e.g., \"environment\": [\"production\", \"prd\", \"development\", \"dev\"]). Every representation of a component must be allowed: the fullname and the shortcode must be one of the allowed values, a missing shortcode is taken from the abbreviation catalogs, and the char must be the char of one of the allowed values. Checked for the provider defaults when the configuration is validated and for the components of every function call.",
 			},
-			"default_domain": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
+			"component_rules": componentRulesSchemaAttribute(),
+			"pattern_fragments": schema.MapAttribute{
+				ElementType: types.StringType,
 				Optional:    true,
-				Description: "Default domain to use when not provided in the function call. Used for resources that require a domain name (e.g., 'contoso.com', 'fabrikam.net').",
+				Description: "Reusable parts of naming patterns, referenced in patterns as {@name} (e.g., \"suffix\": \"{environment:short}-{region:short}\" is used as \"rg-{basename}-{@suffix}\"). Fragments can reference other fragments and the pattern of a resource type as {@@resource_type}.",
 			},
-			"default_criticality": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
+			"resource_type_abbreviations": abbreviationsSchemaAttribute("Abbreviations of resource types keyed by resource type (e.g., \"azurerm_resource_group\": { shortcode = \"rg\" }), used for {resource_type:short} and {resource_type:char} when the resource type only has a fullname. Overrides and extends the built-in abbreviation catalogs of Azure (CAF), AWS and GCP resource types."),
+			"region_abbreviations":        abbreviationsSchemaAttribute("Abbreviations of regions keyed by region name or display name (e.g., \"westeurope\": { shortcode = \"we\" }), used for the {region:short}, {region:char}, {location:short} and {location:char} placeholders when the region or location only has a fullname. Names are compared ignoring case and whitespace. Overrides and extends the built-in region catalogs of Azure, AWS and GCP."),
+			"naming_rules": schema.MapNestedAttribute{
 				Optional:    true,
-				Description: "Default criticality level to use when not provided in the function call. Indicates the importance or criticality of the resource (e.g., 'high', 'medium', 'low', 'mission-critical').",
+				Description: "Naming rules for specific resource types. Keys should match resource types, wildcards of resource types (e.g., \"azurerm_*\") or \"default\" for all other resource types, the most specific key wins. Overrides the built-in naming rules, rules that are not set are inherited from the built-in rules of the resource type and an explicit 0 or empty value (e.g., min_length = 0 or leading_characters = \"\") removes a built-in rule. Resource types without rules only require a name between 3 and 90 characters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"min_length": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Minimum number of characters of the resource name.",
+						},
+						"max_length": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Maximum number of characters of the resource name.",
+						},
+						"allowed_characters": schema.StringAttribute{
+							Optional:    true,
+							Description: "Characters allowed in the resource name, written as a regular expression character class without brackets (e.g., 'a-z0-9-').",
+						},
+						"case": schema.StringAttribute{
+							Optional:    true,
+							Description: "Required case of the resource name. One of 'lower', 'upper' or 'any'.",
+						},
+						"leading_characters": schema.StringAttribute{
+							Optional:    true,
+							Description: "Characters allowed as the first character of the resource name, written as a regular expression character class without brackets (e.g., 'a-z').",
+						},
+						"trailing_characters": schema.StringAttribute{
+							Optional:    true,
+							Description: "Characters allowed as the last character of the resource name, written as a regular expression character class without brackets (e.g., 'a-z0-9').",
+						},
+						"sanitize": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Filters applied to every component value to strip or replace characters the resource type doesn't accept (e.g., ['lower', 'remove:-_.'] or ['replace:_:-']). Supports the same filters as placeholders. An empty list disables the built-in sanitization of the resource type.",
+						},
+						"shortening": shorteningSchemaAttribute("Shortening strategy for names of this resource type that exceed max_length. Overrides the provider wide shortening strategy."),
+						"separator": schema.StringAttribute{
+							Optional:    true,
+							Description: "Separator used for {sep} placeholders in names of this resource type (e.g., '_' for SQL objects). An empty string joins the components without a separator. Overrides default_separator.",
+						},
+						"length_unit": schema.StringAttribute{
+							Optional:    true,
+							Description: "How min_length and max_length are counted: 'characters', 'bytes' of the UTF-8 encoding or 'utf16' code units. Defaults to 'utf16' for Azure, 'bytes' for AWS and 'characters' for other resource types.",
+						},
+					},
+				},
 			},
-
-			// Initiative/solution related
-			"default_initiative": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
+			"hash_seed": schema.StringAttribute{
 				Optional:    true,
-				Description: "Default initiative to use when not provided in the function call. Identifies a broader business initiative the resource belongs to (e.g., 'cloud-migration', 'security-enhancement').",
+				Description: "Seed mixed into the digest produced by {hash:N} placeholders. Changing the seed changes all hashed names, use a different seed per organization or tenant to avoid collisions of globally unique names.",
 			},
-			"default_solution": schema.ObjectAttribute{
-				CustomType:  NewComponentValueType(),
+			"shortening": shorteningSchemaAttribute("Shortening strategy for generated names that exceed the max_length of their resource type. When not configured, names that are too long are rejected."),
+			"default_separator": schema.StringAttribute{
 				Optional:    true,
-				Description: "Default solution to use when not provided in the function call. Identifies the solution architecture or pattern the resource is part of (e.g., 'microservices', 'data-lake').",
+				Description: "Separator used for {sep} placeholders in naming patterns. Defaults to '-'. Can be overridden per resource type with the separator naming rule and per function call with the separator option.",
 			},
-
-			// Extension points
-			"additional_components": schema.MapAttribute{
-				ElementType: NewComponentValueType(),
+			"transliterate": schema.BoolAttribute{
 				Optional:    true,
-				Description: "Additional custom components to use in resource name generation. Keys must be wrapped in curly braces to be used in patterns (e.g., {custom_component1}, {department}). These can be used in custom naming patterns.",
+				Description: "Whether component values are transliterated to ASCII before they are substituted in naming patterns (e.g., 'Zürich' becomes 'Zurich', 'Ørsted' becomes 'Orsted' and 'Straße' becomes 'Strasse'). Defaults to false. Use the ascii filter to transliterate single placeholders or the components of a resource type.",
 			},
-			"additional_naming_patterns": schema.MapAttribute{
-				ElementType: types.StringType,
+			"location_fallback_to_region": schema.BoolAttribute{
 				Optional:    true,
-				Description: "Additional naming patterns for specific resource types. Overrides built-in patterns. Keys should match resource types and values should contain component placeholders (e.g., \"my_custom_resource\": \"prefix-{basename}-{environment:short}\").",
+				Description: "Whether {location} placeholders use the region when neither the function call nor default_location provide a location. Defaults to true. When false, a pattern with {location} requires a location.",
 			},
 		},
 		Description:         providerDescription,
 		MarkdownDescription: providerMarkdownDescription,
 	}
+
+	// The default values of the built-in components are derived from the component registry
+	for name, attribute := range componentSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
 }
 
 // Implement provider data model
@@ -180,35 +168,66 @@ type resourcenamingtoolProviderModel struct {
 	// Instance identifier for the provider
 	ProviderInstanceID types.String `tfsdk:"provider_instance_id" json:"provider_instance_id,omitempty"`
 
+	// Default values of the built-in components keyed by component name, see componentRegistry. They are persisted
+	// through the registry instead of the json struct tags, like the default_<name> fields below.
+	ComponentDefaults types.Map `tfsdk:"component_defaults" json:"-"`
+
+	// Default values of the built-in components that have a default_<name> provider attribute, see componentRegistry.
+	// The fields are found by their tfsdk tag and persisted through the registry instead of the json struct tags.
 	// Core components
-	DefaultResourceType   ComponentValueObject `tfsdk:"default_resource_type" json:"DefaultResourceType,omitempty"`
-	DefaultResourcePrefix ComponentValueObject `tfsdk:"default_resource_prefix" json:"DefaultResourcePrefix,omitempty"`
-	DefaultBasename       ComponentValueObject `tfsdk:"default_basename" json:"DefaultBasename,omitempty"`
-	DefaultEnvironment    ComponentValueObject `tfsdk:"default_environment" json:"DefaultEnvironment,omitempty"`
-	DefaultRegion         ComponentValueObject `tfsdk:"default_region" json:"DefaultRegion,omitempty"`
-	DefaultInstance       ComponentValueObject `tfsdk:"default_instance" json:"DefaultInstance,omitempty"`
+	DefaultResourceType   ComponentValueObject `tfsdk:"default_resource_type" json:"-"`
+	DefaultResourcePrefix ComponentValueObject `tfsdk:"default_resource_prefix" json:"-"`
+	DefaultBasename       ComponentValueObject `tfsdk:"default_basename" json:"-"`
+	DefaultEnvironment    ComponentValueObject `tfsdk:"default_environment" json:"-"`
+	DefaultRegion         ComponentValueObject `tfsdk:"default_region" json:"-"`
+	DefaultInstance       ComponentValueObject `tfsdk:"default_instance" json:"-"`
 
 	// Organization related components
-	DefaultOrganization ComponentValueObject `tfsdk:"default_organization" json:"DefaultOrganization,omitempty"`
-	DefaultBusinessUnit ComponentValueObject `tfsdk:"default_business_unit" json:"DefaultBusinessUnit,omitempty"`
-	DefaultCostCenter   ComponentValueObject `tfsdk:"default_cost_center" json:"DefaultCostCenter,omitempty"`
-	DefaultProject      ComponentValueObject `tfsdk:"default_project" json:"DefaultProject,omitempty"`
-	DefaultApplication  ComponentValueObject `tfsdk:"default_application" json:"DefaultApplication,omitempty"`
-	DefaultWorkload     ComponentValueObject `tfsdk:"default_workload" json:"DefaultWorkload,omitempty"`
+	DefaultOrganization ComponentValueObject `tfsdk:"default_organization" json:"-"`
+	DefaultTenant       ComponentValueObject `tfsdk:"default_tenant" json:"-"`
+	DefaultBusinessUnit ComponentValueObject `tfsdk:"default_business_unit" json:"-"`
+	DefaultCostCenter   ComponentValueObject `tfsdk:"default_cost_center" json:"-"`
+	DefaultProject      ComponentValueObject `tfsdk:"default_project" json:"-"`
+	DefaultApplication  ComponentValueObject `tfsdk:"default_application" json:"-"`
+	DefaultWorkload     ComponentValueObject `tfsdk:"default_workload" json:"-"`
 
 	// Provider specific components
-	DefaultSubscription ComponentValueObject `tfsdk:"default_subscription" json:"DefaultSubscription,omitempty"`
-	DefaultLocation     ComponentValueObject `tfsdk:"default_location" json:"DefaultLocation,omitempty"`
-	DefaultDomain       ComponentValueObject `tfsdk:"default_domain" json:"DefaultDomain,omitempty"`
-	DefaultCriticality  ComponentValueObject `tfsdk:"default_criticality" json:"DefaultCriticality,omitempty"`
+	DefaultSubscription       ComponentValueObject `tfsdk:"default_subscription" json:"-"`
+	DefaultLocation           ComponentValueObject `tfsdk:"default_location" json:"-"`
+	DefaultDomain             ComponentValueObject `tfsdk:"default_domain" json:"-"`
+	DefaultCriticality        ComponentValueObject `tfsdk:"default_criticality" json:"-"`
+	DefaultDataClassification ComponentValueObject `tfsdk:"default_data_classification" json:"-"`
 
 	// Initiative/solution related
-	DefaultInitiative ComponentValueObject `tfsdk:"default_initiative" json:"DefaultInitiative,omitempty"`
-	DefaultSolution   ComponentValueObject `tfsdk:"default_solution" json:"DefaultSolution,omitempty"`
+	DefaultInitiative ComponentValueObject `tfsdk:"default_initiative" json:"-"`
+	DefaultSolution   ComponentValueObject `tfsdk:"default_solution" json:"-"`
+
+	// Built-in catalogs, see builtinPatternSets and builtinAbbreviationCatalogs
+	BuiltinPatternSets        types.List `tfsdk:"builtin_pattern_sets" json:"-"`
+	ResourceTypeAbbreviations types.Map  `tfsdk:"resource_type_abbreviations" json:"-"`
+	RegionAbbreviations       types.Map  `tfsdk:"region_abbreviations" json:"-"`
 
 	// Extension points
 	AdditionalComponents     types.Map `tfsdk:"additional_components" json:"AdditionalComponents,omitempty"`
 	AdditionalNamingPatterns types.Map `tfsdk:"additional_naming_patterns" json:"AdditionalNamingPatterns,omitempty"`
+	PatternFragments         types.Map `tfsdk:"pattern_fragments" json:"PatternFragments,omitempty"`
+	NamingRules              types.Map `tfsdk:"naming_rules" json:"NamingRules,omitempty"`
+
+	// Component value checks, see checkAllowedValue and checkComponentRules
+	AllowedValues  types.Map `tfsdk:"allowed_values" json:"-"`
+	ComponentRules types.Map `tfsdk:"component_rules" json:"-"`
+
+	// Name generation settings
+	Shortening       types.Object `tfsdk:"shortening" json:"-"`
+	HashSeed         types.String `tfsdk:"hash_seed" json:"-"`
+	DefaultSeparator types.String `tfsdk:"default_separator" json:"-"`
+	Transliterate    types.Bool   `tfsdk:"transliterate" json:"-"`
+
+	// Component resolution settings
+	LocationFallbackToRegion types.Bool `tfsdk:"location_fallback_to_region" json:"-"`
+
+	// abbreviations holds the decoded abbreviation attributes keyed by provider attribute, see decodeAbbreviations
+	abbreviations map[string]map[string]abbreviation `tfsdk:"-"`
 }
 
 // MarshalJSON implements custom JSON marshaling for resourcenamingtoolProviderModel
@@ -222,67 +241,11 @@ func (m resourcenamingtoolProviderModel) MarshalJSON() ([]byte, error) {
 		output["provider_instance_id"] = m.ProviderInstanceID.ValueString()
 	}
 
-	// Handle all component value objects
-	// Core components
-	if !m.DefaultResourceType.IsNull() && !m.DefaultResourceType.IsUnknown() {
-		output["DefaultResourceType"] = m.DefaultResourceType
-	}
-	if !m.DefaultResourcePrefix.IsNull() && !m.DefaultResourcePrefix.IsUnknown() {
-		output["DefaultResourcePrefix"] = m.DefaultResourcePrefix
-	}
-	if !m.DefaultBasename.IsNull() && !m.DefaultBasename.IsUnknown() {
-		output["DefaultBasename"] = m.DefaultBasename
-	}
-	if !m.DefaultEnvironment.IsNull() && !m.DefaultEnvironment.IsUnknown() {
-		output["DefaultEnvironment"] = m.DefaultEnvironment
-	}
-	if !m.DefaultRegion.IsNull() && !m.DefaultRegion.IsUnknown() {
-		output["DefaultRegion"] = m.DefaultRegion
-	}
-	if !m.DefaultInstance.IsNull() && !m.DefaultInstance.IsUnknown() {
-		output["DefaultInstance"] = m.DefaultInstance
-	}
-
-	// Organization related components
-	if !m.DefaultOrganization.IsNull() && !m.DefaultOrganization.IsUnknown() {
-		output["DefaultOrganization"] = m.DefaultOrganization
-	}
-	if !m.DefaultBusinessUnit.IsNull() && !m.DefaultBusinessUnit.IsUnknown() {
-		output["DefaultBusinessUnit"] = m.DefaultBusinessUnit
-	}
-	if !m.DefaultCostCenter.IsNull() && !m.DefaultCostCenter.IsUnknown() {
-		output["DefaultCostCenter"] = m.DefaultCostCenter
-	}
-	if !m.DefaultProject.IsNull() && !m.DefaultProject.IsUnknown() {
-		output["DefaultProject"] = m.DefaultProject
-	}
-	if !m.DefaultApplication.IsNull() && !m.DefaultApplication.IsUnknown() {
-		output["DefaultApplication"] = m.DefaultApplication
-	}
-	if !m.DefaultWorkload.IsNull() && !m.DefaultWorkload.IsUnknown() {
-		output["DefaultWorkload"] = m.DefaultWorkload
-	}
-
-	// Provider specific components
-	if !m.DefaultSubscription.IsNull() && !m.DefaultSubscription.IsUnknown() {
-		output["DefaultSubscription"] = m.DefaultSubscription
-	}
-	if !m.DefaultLocation.IsNull() && !m.DefaultLocation.IsUnknown() {
-		output["DefaultLocation"] = m.DefaultLocation
-	}
-	if !m.DefaultDomain.IsNull() && !m.DefaultDomain.IsUnknown() {
-		output["DefaultDomain"] = m.DefaultDomain
-	}
-	if !m.DefaultCriticality.IsNull() && !m.DefaultCriticality.IsUnknown() {
-		output["DefaultCriticality"] = m.DefaultCriticality
-	}
-
-	// Initiative/solution related
-	if !m.DefaultInitiative.IsNull() && !m.DefaultInitiative.IsUnknown() {
-		output["DefaultInitiative"] = m.DefaultInitiative
-	}
-	if !m.DefaultSolution.IsNull() && !m.DefaultSolution.IsUnknown() {
-		output["DefaultSolution"] = m.DefaultSolution
+	// Handle the default values of the built-in components
+	for _, definition := range componentRegistry {
+		if value := definition.Default(m); !value.IsNull() && !value.IsUnknown() {
+			output[definition.ConfigKey()] = value
+		}
 	}
 
 	// Handle AdditionalComponents map
@@ -300,6 +263,29 @@ func (m resourcenamingtoolProviderModel) MarshalJSON() ([]byte, error) {
 		output["AdditionalComponents"] = componentsMap
 	}
 
+	// Handle BuiltinPatternSets list
+	if !m.BuiltinPatternSets.IsNull() && !m.BuiltinPatternSets.IsUnknown() {
+		output["BuiltinPatternSets"] = selectedPatternSets(m)
+	}
+
+	// Handle ResourceTypeAbbreviations map
+	if !m.ResourceTypeAbbreviations.IsNull() && !m.ResourceTypeAbbreviations.IsUnknown() {
+		abbreviations, diags := abbreviationsFromMap(context.Background(), m.ResourceTypeAbbreviations)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to marshal resource type abbreviations: %s", diags.Errors()[0].Detail())
+		}
+		output["ResourceTypeAbbreviations"] = abbreviations
+	}
+
+	// Handle RegionAbbreviations map
+	if !m.RegionAbbreviations.IsNull() && !m.RegionAbbreviations.IsUnknown() {
+		abbreviations, diags := abbreviationsFromMap(context.Background(), m.RegionAbbreviations)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to marshal region abbreviations: %s", diags.Errors()[0].Detail())
+		}
+		output["RegionAbbreviations"] = abbreviations
+	}
+
 	// Handle AdditionalNamingPatterns map
 	if !m.AdditionalNamingPatterns.IsNull() && !m.AdditionalNamingPatterns.IsUnknown() {
 		patternsMap := make(map[string]interface{})
@@ -312,6 +298,67 @@ func (m resourcenamingtoolProviderModel) MarshalJSON() ([]byte, error) {
 		output["AdditionalNamingPatterns"] = patternsMap
 	}
 
+	// Handle PatternFragments map
+	if !m.PatternFragments.IsNull() && !m.PatternFragments.IsUnknown() {
+		output["PatternFragments"] = stringMapValues(m.PatternFragments)
+	}
+
+	// Handle NamingRules map
+	if !m.NamingRules.IsNull() && !m.NamingRules.IsUnknown() {
+		rules, diags := namingRulesFromMap(context.Background(), m.NamingRules)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to marshal naming rules: %s", diags.Errors()[0].Detail())
+		}
+		output["NamingRules"] = rules
+	}
+
+	// Handle Shortening object
+	if !m.Shortening.IsNull() && !m.Shortening.IsUnknown() {
+		strategy, diags := shorteningFromObject(context.Background(), m.Shortening)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to marshal shortening strategy: %s", diags.Errors()[0].Detail())
+		}
+		output["Shortening"] = strategy
+	}
+
+	// Handle AllowedValues map
+	if !m.AllowedValues.IsNull() && !m.AllowedValues.IsUnknown() {
+		allowed, diags := allowedComponentValues(context.Background(), m)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to marshal allowed values: %s", diags.Errors()[0].Detail())
+		}
+		output["AllowedValues"] = allowed
+	}
+
+	// Handle ComponentRules map
+	if !m.ComponentRules.IsNull() && !m.ComponentRules.IsUnknown() {
+		rules, diags := componentRulesFromMap(context.Background(), m.ComponentRules)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to marshal component rules: %s", diags.Errors()[0].Detail())
+		}
+		output["ComponentRules"] = rules
+	}
+
+	// Handle HashSeed string
+	if !m.HashSeed.IsNull() && !m.HashSeed.IsUnknown() {
+		output["HashSeed"] = m.HashSeed.ValueString()
+	}
+
+	// Handle DefaultSeparator string, an empty separator is a valid value
+	if !m.DefaultSeparator.IsNull() && !m.DefaultSeparator.IsUnknown() {
+		output["DefaultSeparator"] = m.DefaultSeparator.ValueString()
+	}
+
+	// Handle Transliterate bool
+	if !m.Transliterate.IsNull() && !m.Transliterate.IsUnknown() {
+		output["Transliterate"] = m.Transliterate.ValueBool()
+	}
+
+	// Handle LocationFallbackToRegion bool
+	if !m.LocationFallbackToRegion.IsNull() && !m.LocationFallbackToRegion.IsUnknown() {
+		output["LocationFallbackToRegion"] = m.LocationFallbackToRegion.ValueBool()
+	}
+
 	return json.Marshal(output)
 }
 
@@ -360,6 +407,18 @@ func (p *resourcenamingtoolFunctionsProvider) Functions(ctx context.Context) []f
 		func() function.Function {
 			return NewGenerateResourceNameFunction(p.config)
 		},
+		func() function.Function {
+			return NewGenerateResourceNameDetailsFunction(p.config)
+		},
+		func() function.Function {
+			return NewGenerateResourceNamesFunction(p.config)
+		},
+		func() function.Function {
+			return NewParseResourceNameFunction(p.config)
+		},
+		func() function.Function {
+			return NewValidateResourceNameFunction(p.config)
+		},
 	}
 }
 
@@ -406,6 +465,16 @@ func (p *resourcenamingtoolFunctionsProvider) ValidateConfig(ctx context.Context
 				logDebug(ctx, "Valid component value for key: %s", key)
 			}
 		}
+		// Component values are inserted into names as literals and cannot contain pattern syntax
+		for name, values := range getProviderAdditionalComponentGroups(ctx, config) {
+			if attribute, value, metacharacter := findComponentMetacharacter(values); metacharacter != "" {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("additional_components"),
+					"Invalid Component Value",
+					fmt.Sprintf("The %s %q of component {%s} contains %q, which is reserved for naming patterns", attribute, value, name, metacharacter),
+				)
+			}
+		}
 	} else {
 		logDebug(ctx, "No additional components provided or they are unknown")
 	}
@@ -427,9 +496,49 @@ func (p *resourcenamingtoolFunctionsProvider) ValidateConfig(ctx context.Context
 				continue
 			}
 
-			// Check that pattern values contain at least one component placeholder
 			patternStr, ok := value.(types.String)
-			if ok && !strings.Contains(patternStr.ValueString(), "{") {
+			if !ok || patternStr.IsUnknown() {
+				continue
+			}
+
+			// Check that the key is a resource type, a wildcard such as "azurerm_*_database" or "default"
+			if err := validateResourceTypeKey(key); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("additional_naming_patterns"),
+					"Invalid Resource Type",
+					fmt.Sprintf("Naming pattern key is invalid: %s", err.Error()),
+				)
+				continue
+			}
+
+			// Check that pattern values can be parsed
+			parsedPattern, err := parseNamingPattern(patternStr.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("additional_naming_patterns"),
+					"Invalid Naming Pattern",
+					fmt.Sprintf("Naming pattern %q for resource type %q is invalid: %s", patternStr.ValueString(), key, err.Error()),
+				)
+				logDebug(ctx, "Invalid naming pattern syntax for resource type %s: %s", key, err.Error())
+				continue
+			}
+
+			// Check that the pattern fragments and naming patterns referenced by the pattern exist and don't form a cycle
+			if !config.PatternFragments.IsUnknown() {
+				parsedPattern, err = parsedPattern.expand(configuredPatternReferences(config), patternReference(patternReferenceNode, key))
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("additional_naming_patterns"),
+						patternExpansionSummary(err, "Invalid Naming Pattern"),
+						fmt.Sprintf("Naming pattern %q for resource type %q is invalid: %s", patternStr.ValueString(), key, err.Error()),
+					)
+					logDebug(ctx, "Invalid pattern reference for resource type %s: %s", key, err.Error())
+					continue
+				}
+			}
+
+			// Check that pattern values contain at least one component placeholder
+			if len(parsedPattern.placeholders()) == 0 {
 				resp.Diagnostics.AddAttributeWarning(
 					path.Root("additional_naming_patterns"),
 					"Invalid Naming Pattern",
@@ -444,8 +553,149 @@ func (p *resourcenamingtoolFunctionsProvider) ValidateConfig(ctx context.Context
 		logDebug(ctx, "No additional naming patterns provided or they are unknown")
 	}
 
+	// Validate the built-in pattern sets if provided
+	logDebug(ctx, "Validating built-in pattern sets...")
+	if !config.BuiltinPatternSets.IsNull() && !config.BuiltinPatternSets.IsUnknown() {
+		for i, name := range selectedPatternSets(config) {
+			if _, ok := builtinPatternSets[name]; !ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("builtin_pattern_sets").AtListIndex(i),
+					"Invalid Pattern Set",
+					fmt.Sprintf("Pattern set %q is not supported, expected one of: %s", name, strings.Join(patternSetNames(), ", ")),
+				)
+				logDebug(ctx, "Invalid pattern set: %s", name)
+			}
+		}
+	} else {
+		logDebug(ctx, "No built-in pattern sets provided or they are unknown")
+	}
+
+	// Validate the abbreviations if provided, they are inserted into names as literals like the component values
+	validateAbbreviations := func(abbreviations types.Map, attrName string) {
+		logDebug(ctx, "Validating abbreviations: %s", attrName)
+		if abbreviations.IsNull() || abbreviations.IsUnknown() {
+			logDebug(ctx, "No abbreviations provided or they are unknown: %s", attrName)
+			return
+		}
+		entries, diags := abbreviationsFromMap(ctx, abbreviations)
+		resp.Diagnostics.Append(diags...)
+		keys := make(map[string]string, len(entries))
+		for fullname, abbr := range entries {
+			// Fullnames are looked up ignoring case and whitespace, so they must remain unique
+			if other, ok := keys[abbreviationKey(fullname)]; ok {
+				first, second := other, fullname
+				if second < first {
+					first, second = second, first
+				}
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attrName).AtMapKey(second),
+					"Invalid Abbreviation",
+					fmt.Sprintf("%q and %q in %s are the same name when case and whitespace are ignored", first, second, attrName),
+				)
+				continue
+			}
+			keys[abbreviationKey(fullname)] = fullname
+
+			for _, problem := range abbr.validate(fullname, attrName) {
+				resp.Diagnostics.AddAttributeError(path.Root(attrName).AtMapKey(fullname), "Invalid Abbreviation", problem)
+			}
+		}
+	}
+	validateAbbreviations(config.ResourceTypeAbbreviations, "resource_type_abbreviations")
+	validateAbbreviations(config.RegionAbbreviations, "region_abbreviations")
+
+	// Validate pattern fragments if provided
+	logDebug(ctx, "Validating pattern fragments...")
+	if !config.PatternFragments.IsNull() && !config.PatternFragments.IsUnknown() {
+		references := configuredPatternReferences(config)
+		for name, fragment := range references.Fragments {
+			if !isValidPlaceholderName(name) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("pattern_fragments").AtMapKey(name),
+					"Invalid Pattern Fragment",
+					fmt.Sprintf("Pattern fragment name %q is invalid, only letters, digits and underscores are allowed", name),
+				)
+				continue
+			}
+			parsedFragment, err := parseNamingPattern(fragment)
+			if err == nil {
+				_, err = parsedFragment.expand(references, patternReference(fragmentNode, name))
+			}
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("pattern_fragments").AtMapKey(name),
+					patternExpansionSummary(err, "Invalid Pattern Fragment"),
+					fmt.Sprintf("Pattern fragment %q with value %q is invalid: %s", name, fragment, err.Error()),
+				)
+				logDebug(ctx, "Invalid pattern fragment %s: %s", name, err.Error())
+				continue
+			}
+			logDebug(ctx, "Valid pattern fragment: %s", name)
+		}
+	} else {
+		logDebug(ctx, "No pattern fragments provided or they are unknown")
+	}
+
+	// Validate naming rules if provided
+	logDebug(ctx, "Validating naming rules...")
+	if !config.NamingRules.IsNull() && !config.NamingRules.IsUnknown() {
+		rules, diags := namingRulesFromMap(ctx, config.NamingRules)
+		resp.Diagnostics.Append(diags...)
+		for resourceType, rule := range rules {
+			// Check that the key is a resource type, a wildcard such as "azurerm_*_database" or "default"
+			if err := validateResourceTypeKey(resourceType); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("naming_rules").AtMapKey(resourceType),
+					"Invalid Resource Type",
+					fmt.Sprintf("Naming rules key is invalid: %s", err.Error()),
+				)
+				continue
+			}
+			if err := rule.constraint().validate(); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("naming_rules").AtMapKey(resourceType),
+					"Invalid Naming Rule",
+					fmt.Sprintf("Naming rules for resource type %q are invalid: %s", resourceType, err.Error()),
+				)
+				logDebug(ctx, "Invalid naming rules for resource type %s: %s", resourceType, err.Error())
+				continue
+			}
+			if err := checkNamingRuleConflicts(ctx, config, rules, resourceType); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("naming_rules").AtMapKey(resourceType),
+					"Invalid Naming Rule",
+					fmt.Sprintf("Naming rules for resource type %q conflict with the built-in naming rules: %s", resourceType, err.Error()),
+				)
+				continue
+			}
+			logDebug(ctx, "Valid naming rules for resource type: %s", resourceType)
+		}
+	} else {
+		logDebug(ctx, "No naming rules provided or they are unknown")
+	}
+
+	// Validate the provider wide shortening strategy if provided
+	logDebug(ctx, "Validating shortening strategy...")
+	strategy, diags := shorteningFromObject(ctx, config.Shortening)
+	resp.Diagnostics.Append(diags...)
+	if strategy != nil {
+		if err := strategy.validate(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("shortening"),
+				"Invalid Shortening Strategy",
+				fmt.Sprintf("Shortening strategy is invalid: %s", err.Error()),
+			)
+			logDebug(ctx, "Invalid shortening strategy: %s", err.Error())
+		} else {
+			logDebug(ctx, "Valid shortening strategy: %v", strategy.steps())
+		}
+	} else {
+		logDebug(ctx, "No shortening strategy provided or it is unknown")
+	}
+
 	// If component values are provided, validate them
-	validateComponentIfProvided := func(comp ComponentValueObject, attrName string) {
+	validateComponentIfProvided := func(comp ComponentValueObject, attrPath path.Path) {
+		attrName := attrPath.String()
 		logDebug(ctx, "Validating component: %s", attrName)
 		if comp.IsNull() || comp.IsUnknown() {
 			logDebug(ctx, "Component is null or unknown: %s", attrName)
@@ -463,11 +713,18 @@ func (p *resourcenamingtoolFunctionsProvider) ValidateConfig(ctx context.Context
 			(diagShort.HasError() || shortcode == "") &&
 			(diagChar.HasError() || char == "") {
 			resp.Diagnostics.AddAttributeError(
-				path.Root(attrName),
+				attrPath,
 				"Invalid Component Configuration",
 				fmt.Sprintf("At least one of fullname, shortcode, or char must be provided for %s", attrName),
 			)
 			logDebug(ctx, "Invalid component configuration for %s", attrName)
+		} else if attribute, value, metacharacter := findComponentMetacharacter(map[string]string{"fullname": fullname, "shortcode": shortcode, "char": char}); metacharacter != "" {
+			resp.Diagnostics.AddAttributeError(
+				attrPath,
+				"Invalid Component Configuration",
+				fmt.Sprintf("The %s %q of %s contains %q, which is reserved for naming patterns", attribute, value, attrName, metacharacter),
+			)
+			logDebug(ctx, "Component value of %s contains pattern syntax", attrName)
 		} else {
 			// Print valid component configuration's fullname, shortcode, and char
 			logDebug(ctx, "Valid component configuration for %s: fullname: %s shortcode: %s char: %s",
@@ -475,31 +732,130 @@ func (p *resourcenamingtoolFunctionsProvider) ValidateConfig(ctx context.Context
 		}
 	}
 
-	// Validate core components if provided
-	validateComponentIfProvided(config.DefaultResourceType, "default_resource_type")
-	validateComponentIfProvided(config.DefaultResourcePrefix, "default_resource_prefix")
-	validateComponentIfProvided(config.DefaultBasename, "default_basename")
-	validateComponentIfProvided(config.DefaultEnvironment, "default_environment")
-	validateComponentIfProvided(config.DefaultRegion, "default_region")
-	validateComponentIfProvided(config.DefaultInstance, "default_instance")
-
-	// Validate organization components if provided
-	validateComponentIfProvided(config.DefaultOrganization, "default_organization")
-	validateComponentIfProvided(config.DefaultBusinessUnit, "default_business_unit")
-	validateComponentIfProvided(config.DefaultCostCenter, "default_cost_center")
-	validateComponentIfProvided(config.DefaultProject, "default_project")
-	validateComponentIfProvided(config.DefaultApplication, "default_application")
-	validateComponentIfProvided(config.DefaultWorkload, "default_workload")
-
-	// Validate provider specific components if provided
-	validateComponentIfProvided(config.DefaultSubscription, "default_subscription")
-	validateComponentIfProvided(config.DefaultLocation, "default_location")
-	validateComponentIfProvided(config.DefaultDomain, "default_domain")
-	validateComponentIfProvided(config.DefaultCriticality, "default_criticality")
-
-	// Validate initiative/solution components if provided
-	validateComponentIfProvided(config.DefaultInitiative, "default_initiative")
-	validateComponentIfProvided(config.DefaultSolution, "default_solution")
+	// Validate the default separator, it is inserted into names as a literal like the component values
+	if metacharacter := findPatternMetacharacter(config.DefaultSeparator.ValueString()); metacharacter != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_separator"),
+			"Invalid Separator",
+			fmt.Sprintf("Separator %q contains %q, which is reserved for naming patterns", config.DefaultSeparator.ValueString(), metacharacter),
+		)
+	}
+
+	// Validate the default values of the built-in components if provided
+	for _, definition := range componentRegistry {
+		if definition.HasProviderAttribute() {
+			validateComponentIfProvided(definition.providerAttributeValue(config), path.Root(definition.ProviderAttribute()))
+		}
+	}
+
+	// Validate the component_defaults, which only accept the built-in components
+	if !config.ComponentDefaults.IsNull() && !config.ComponentDefaults.IsUnknown() {
+		for name := range config.ComponentDefaults.Elements() {
+			definition, ok := lookupComponentDefinition(name)
+			if !ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(componentDefaultsAttribute).AtMapKey(name),
+					"Unknown Component",
+					fmt.Sprintf("Component %s is not a built-in component, use additional_components to define the value of a custom component", name),
+				)
+				continue
+			}
+			value, _ := componentDefaultsEntry(config, name)
+			validateComponentIfProvided(value, path.Root(componentDefaultsAttribute).AtMapKey(name))
+			if definition.HasProviderAttribute() && !value.IsNull() && !definition.providerAttributeValue(config).IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(componentDefaultsAttribute).AtMapKey(name),
+					"Conflicting Component Defaults",
+					fmt.Sprintf("The default value of component %s is set in both component_defaults and %s, set only one of them", name, definition.ProviderAttribute()),
+				)
+			}
+		}
+	}
+
+	// Validate the allowed values and check the default values of the components against them
+	logDebug(ctx, "Validating allowed values...")
+	allowed, diags := allowedComponentValues(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if len(allowed) > 0 {
+		additionalGroups := getProviderAdditionalComponentGroups(ctx, config)
+		for name, values := range allowed {
+			if len(values) == 0 {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("allowed_values").AtMapKey(name),
+					"Invalid Allowed Values",
+					fmt.Sprintf("The allowed values of component %s cannot be empty", name),
+				)
+				continue
+			}
+			if _, isBuiltin := lookupComponentDefinition(name); !isBuiltin {
+				if _, isAdditional := additionalGroups[name]; !isAdditional {
+					resp.Diagnostics.AddAttributeWarning(
+						path.Root("allowed_values").AtMapKey(name),
+						"Unknown Component",
+						fmt.Sprintf("Component %s is neither a built-in component nor one of the additional_components, its allowed values only apply to the additional_components of function calls", name),
+					)
+				}
+			}
+		}
+		// Malformed abbreviations are reported by the validation of the abbreviations, the values can't be checked without them
+		if !decodeAbbreviations(ctx, &config).HasError() {
+			for _, definition := range componentRegistry {
+				if err := checkAllowedValue(config, allowed, definition.Name, componentValueStrings(ctx, definition.Default(config))); err != nil {
+					resp.Diagnostics.AddAttributeError(definition.DefaultPath(config), "Value Not Allowed", "The "+err.Error())
+				}
+			}
+			for name, values := range additionalGroups {
+				if err := checkAllowedValue(config, allowed, name, values); err != nil {
+					resp.Diagnostics.AddAttributeError(path.Root("additional_components").AtMapKey("{"+name+"}"), "Value Not Allowed", "The "+err.Error())
+				}
+			}
+		}
+	} else {
+		logDebug(ctx, "No allowed values provided or they are unknown")
+	}
+
+	// Validate the component rules and check the default values of the components against them
+	logDebug(ctx, "Validating component rules...")
+	componentRules, diags := componentRulesFromMap(ctx, config.ComponentRules)
+	resp.Diagnostics.Append(diags...)
+	if len(componentRules) > 0 {
+		additionalGroups := getProviderAdditionalComponentGroups(ctx, config)
+		for name, rule := range componentRules {
+			for attribute, valueRule := range rule.representations() {
+				if err := valueRule.validate(); err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("component_rules").AtMapKey(name).AtName(attribute),
+						"Invalid Component Rule",
+						fmt.Sprintf("The %s rule of component %s is invalid: %s", attribute, name, err.Error()),
+					)
+				}
+			}
+			if name == componentRulesWildcard {
+				continue
+			}
+			if _, isBuiltin := lookupComponentDefinition(name); !isBuiltin {
+				if _, isAdditional := additionalGroups[name]; !isAdditional {
+					resp.Diagnostics.AddAttributeWarning(
+						path.Root("component_rules").AtMapKey(name),
+						"Unknown Component",
+						fmt.Sprintf("Component %s is neither a built-in component nor one of the additional_components, its rules only apply to the additional_components of function calls", name),
+					)
+				}
+			}
+		}
+		for _, definition := range componentRegistry {
+			for _, err := range checkComponentRules(componentRules, definition.Name, componentValueStrings(ctx, definition.Default(config))) {
+				resp.Diagnostics.AddAttributeError(definition.DefaultPath(config), "Invalid Component Value", "The "+err.Error())
+			}
+		}
+		for name, values := range additionalGroups {
+			for _, err := range checkComponentRules(componentRules, name, values) {
+				resp.Diagnostics.AddAttributeError(path.Root("additional_components").AtMapKey("{"+name+"}"), "Invalid Component Value", "The "+err.Error())
+			}
+		}
+	} else {
+		logDebug(ctx, "No component rules provided or they are unknown")
+	}
 
 	if resp.Diagnostics.HasError() {
 		logError(ctx, "Validation errors detected, not saving configuration")
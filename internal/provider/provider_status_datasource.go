@@ -41,6 +41,11 @@ func (d *ProviderStatusDataSource) Schema(_ context.Context, _ datasource.Schema
 				Description: "Version of Go used to build the provider",
 				Computed:    true,
 			},
+			"builtin_pattern_sets": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Versions of the built-in pattern sets, keyed by the name of the pattern set",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -52,9 +57,16 @@ func (d *ProviderStatusDataSource) Read(ctx context.Context, req datasource.Read
 	// Set computed attributes
 	state.ProviderVersion = types.StringValue("dev") // This would normally be set via build flags
 	state.GoVersion = types.StringValue(runtime.Version())
+	versions := make(map[string]string, len(builtinPatternSets))
+	for name, set := range builtinPatternSets {
+		versions[name] = set.Version
+	}
+	patternSets, diags := types.MapValueFrom(ctx, types.StringType, versions)
+	resp.Diagnostics.Append(diags...)
+	state.BuiltinPatternSets = patternSets
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -65,4 +77,6 @@ func (d *ProviderStatusDataSource) Read(ctx context.Context, req datasource.Read
 type providerStatusModel struct {
 	ProviderVersion types.String `tfsdk:"provider_version"`
 	GoVersion       types.String `tfsdk:"go_version"`
+	// BuiltinPatternSets maps the name of every built-in pattern set to its version
+	BuiltinPatternSets types.Map `tfsdk:"builtin_pattern_sets"`
 }
@@ -27,6 +27,11 @@ data "resourcenamingtool_status" "test" {}
 						"data.resourcenamingtool_status.test",
 						"go_version",
 					),
+					resource.TestCheckResourceAttr(
+						"data.resourcenamingtool_status.test",
+						"builtin_pattern_sets.azure_caf",
+						"1.0.0",
+					),
 				),
 			},
 		},
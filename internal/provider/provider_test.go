@@ -3,6 +3,10 @@
 package provider
 
 import (
+	"fmt"
+	"os"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -15,6 +19,20 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"resourcenamingtool": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// TestMain saves the provider configuration file of the tests in a temporary directory instead of the package
+// directory
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "resourcenamingtool-test")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create the configuration directory: %s\n", err)
+		os.Exit(1)
+	}
+	configBaseDir = func() (string, error) { return dir, nil }
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 // providerConfig is a shared configuration to combine with the actual
 // test configuration so the provider is properly configured.
 const providerConfig = `
@@ -40,70 +40,25 @@ func init() {
 func NewResourceNamingParametersType() *ResourceNamingParametersType {
 	componentType := NewComponentValueType()
 
-	// Create attribute types map for our resource naming parameters
+	// Create attribute types map for our resource naming parameters, with the extension points for custom
+	// components, patterns and options
 	attrTypes := map[string]attr.Type{
-		// Core components
-		"resource_type":   componentType,
-		"resource_prefix": componentType,
-		"basename":        componentType,
-		"environment":     componentType,
-		"region":          componentType,
-		"instance":        componentType,
-
-		// Organization related components
-		"organization":  componentType,
-		"business_unit": componentType,
-		"cost_center":   componentType,
-		"project":       componentType,
-		"application":   componentType,
-		"workload":      componentType,
-
-		// Provider specific components
-		"subscription": componentType,
-		"location":     componentType,
-		"domain":       componentType,
-		"criticality":  componentType,
-
-		// Initiative/solution related
-		"initiative": componentType,
-		"solution":   componentType,
-
-		// Extension points - for custom components and patterns
 		"additional_components":      types.MapType{ElemType: componentType},
 		"additional_naming_patterns": types.MapType{ElemType: types.StringType},
+		"options":                    types.MapType{ElemType: types.StringType},
 	}
 
 	// Create corresponding tftypes map for our Terraform type representation
 	tfAttrTypes := map[string]tftypes.Type{
-		// Core components
-		"resource_type":   componentType.TerraformType(context.TODO()),
-		"resource_prefix": componentType.TerraformType(context.TODO()),
-		"basename":        componentType.TerraformType(context.TODO()),
-		"environment":     componentType.TerraformType(context.TODO()),
-		"region":          componentType.TerraformType(context.TODO()),
-		"instance":        componentType.TerraformType(context.TODO()),
-
-		// Organization related components
-		"organization":  componentType.TerraformType(context.TODO()),
-		"business_unit": componentType.TerraformType(context.TODO()),
-		"cost_center":   componentType.TerraformType(context.TODO()),
-		"project":       componentType.TerraformType(context.TODO()),
-		"application":   componentType.TerraformType(context.TODO()),
-		"workload":      componentType.TerraformType(context.TODO()),
-
-		// Provider specific components
-		"subscription": componentType.TerraformType(context.TODO()),
-		"location":     componentType.TerraformType(context.TODO()),
-		"domain":       componentType.TerraformType(context.TODO()),
-		"criticality":  componentType.TerraformType(context.TODO()),
-
-		// Initiative/solution related
-		"initiative": componentType.TerraformType(context.TODO()),
-		"solution":   componentType.TerraformType(context.TODO()),
-
-		// Extension points
 		"additional_components":      tftypes.Map{ElementType: componentType.TerraformType(context.TODO())},
 		"additional_naming_patterns": tftypes.Map{ElementType: tftypes.String},
+		"options":                    tftypes.Map{ElementType: tftypes.String},
+	}
+
+	// Every built-in component of the registry is a parameter
+	for _, definition := range componentRegistry {
+		attrTypes[definition.Name] = componentType
+		tfAttrTypes[definition.Name] = componentType.TerraformType(context.TODO())
 	}
 
 	optionalAttrs := map[string]struct{}{}
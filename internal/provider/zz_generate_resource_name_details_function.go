@@ -0,0 +1,181 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	_ "embed" // Import the embed package
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+//go:embed descriptions/generate_resource_name_details_description.txt
+var generateResourceNameDetailsDescription string
+
+//go:embed descriptions/generate_resource_name_details_markdown_description.md
+var generateResourceNameDetailsMarkdownDescription string
+
+// Sources of the naming patterns, reported by generate_resource_name_details
+const (
+	patternSourceBuiltin      = "built_in"
+	patternSourceProvider     = "provider"
+	patternSourceFunctionCall = "function_call"
+)
+
+// GenerateResourceNameDetailsFunction implements function.Function, it generates a resource name and explains how
+// the name was generated
+type GenerateResourceNameDetailsFunction struct {
+	// Store the provider configuration pointer itself
+	config *resourcenamingtoolProviderModel
+}
+
+// NewGenerateResourceNameDetailsFunction creates a new instance with the provider config
+func NewGenerateResourceNameDetailsFunction(config *resourcenamingtoolProviderModel) function.Function {
+	return &GenerateResourceNameDetailsFunction{
+		config: config,
+	}
+}
+
+// resourceNameDetails is the result of the generate_resource_name_details function
+type resourceNameDetails struct {
+	// Name is the generated resource name
+	Name string `tfsdk:"name"`
+	// ResourceType is the resource type the name was generated for
+	ResourceType string `tfsdk:"resource_type"`
+	// Pattern is the naming pattern that was used, before the expansion of fragments and references
+	Pattern string `tfsdk:"pattern"`
+	// PatternKey is the key of the naming pattern, the resource type itself, a wildcard pattern or "default"
+	PatternKey string `tfsdk:"pattern_key"`
+	// PatternSource is where the naming pattern came from, one of the patternSource constants
+	PatternSource string `tfsdk:"pattern_source"`
+	// PatternSet is the name and version of the built-in pattern set of the naming pattern, e.g. "azure_caf@1.0.0",
+	// empty when the pattern doesn't come from a pattern set
+	PatternSet string `tfsdk:"pattern_set"`
+	// Shortening holds the shortening steps that were applied to fit the name in the max_length of the resource type
+	Shortening []string `tfsdk:"shortening"`
+	// Placeholders holds the resolved placeholders of the name, in the order they appear in the pattern
+	Placeholders []placeholderResolution `tfsdk:"placeholders"`
+}
+
+// placeholderResolution describes how the value of a placeholder of the generated name was resolved
+type placeholderResolution struct {
+	// Placeholder is the placeholder as written in the pattern, e.g. "{environment:short}"
+	Placeholder string `tfsdk:"placeholder"`
+	// Column is the column of the placeholder in the pattern
+	Column int64 `tfsdk:"column"`
+	// Component is the canonical name of the component, empty for the separator and hash placeholders
+	Component string `tfsdk:"component"`
+	// Format is the representation of the component that was used, e.g. "short" or "%03d", it differs from the
+	// placeholder when the component was abbreviated to shorten the name
+	Format string `tfsdk:"format"`
+	// Value is the value of the placeholder in the name, after filters and sanitization
+	Value string `tfsdk:"value"`
+	// Source is where the value came from, one of the valueSource constants
+	Source string `tfsdk:"source"`
+	// Derivation describes how the value was derived from the source, empty when it is used as is
+	Derivation string `tfsdk:"derivation"`
+}
+
+// placeholderResolutionAttributeTypes are the attribute types of the placeholders returned by
+// generate_resource_name_details
+var placeholderResolutionAttributeTypes = map[string]attr.Type{
+	"placeholder": types.StringType,
+	"column":      types.Int64Type,
+	"component":   types.StringType,
+	"format":      types.StringType,
+	"value":       types.StringType,
+	"source":      types.StringType,
+	"derivation":  types.StringType,
+}
+
+// resourceNameDetailsAttributeTypes are the attribute types of the object returned by generate_resource_name_details
+var resourceNameDetailsAttributeTypes = map[string]attr.Type{
+	"name":           types.StringType,
+	"resource_type":  types.StringType,
+	"pattern":        types.StringType,
+	"pattern_key":    types.StringType,
+	"pattern_source": types.StringType,
+	"pattern_set":    types.StringType,
+	"shortening":     types.ListType{ElemType: types.StringType},
+	"placeholders":   types.ListType{ElemType: types.ObjectType{AttrTypes: placeholderResolutionAttributeTypes}},
+}
+
+// newPlaceholderResolution returns the resolution of a placeholder with the given value and source
+func newPlaceholderResolution(node patternNode, value string, source valueSource) placeholderResolution {
+	resolution := placeholderResolution{
+		Placeholder: node.Text,
+		Column:      int64(node.Column),
+		Value:       value,
+		Source:      source.Source,
+		Derivation:  source.Derivation,
+	}
+	if isComponentPlaceholder(node) {
+		resolution.Component = canonicalComponentName(node.Name)
+		resolution.Format = placeholderFormat(node)
+	}
+	return resolution
+}
+
+func (f *GenerateResourceNameDetailsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "generate_resource_name_details"
+}
+
+func (f *GenerateResourceNameDetailsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	logDebug(ctx, "Defining generate_resource_name_details function")
+
+	resp.Definition = function.Definition{
+		Summary:             "Generate a resource name and explain which pattern and values were used.",
+		Description:         generateResourceNameDetailsDescription,
+		MarkdownDescription: generateResourceNameDetailsMarkdownDescription,
+		Parameters: []function.Parameter{
+			function.SetParameter{
+				Name:        "parameters",
+				Description: "A set of parameters used to generate the resource name, the same as for generate_resource_name.",
+				ElementType: types.MapType{
+					ElemType: types.MapType{
+						ElemType: types.StringType,
+					},
+				},
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: resourceNameDetailsAttributeTypes,
+		},
+	}
+}
+
+func (f *GenerateResourceNameDetailsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	logDebug(ctx, "Invoking GenerateResourceNameDetailsFunction...")
+
+	var parametersSet types.Set
+	resp.Error = req.Arguments.Get(ctx, &parametersSet)
+	if resp.Error != nil {
+		return
+	}
+
+	resourceParams, err := setWithNestedMapsToResourceNamingParametersValue(ctx, parametersSet)
+	if err != nil {
+		logError(ctx, "Failed to convert parameters: %s", err.Error())
+		resp.Error = function.NewFuncError("Failed to convert parameters: " + err.Error())
+		return
+	}
+
+	// The resource type falls back to the default_resource_type of the provider, like the other components
+	config := functionProviderConfig(ctx, f.config)
+	details, diags := generateResourceNameDetails(ctx, resourceParams, config)
+	if funcErr := functionErrorFromDiagnostics(ctx, diags); funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	logDebugWithFields(ctx, "Generated resource name details", map[string]interface{}{
+		"name":           details.Name,
+		"pattern":        details.Pattern,
+		"pattern_source": details.PatternSource,
+	})
+	resp.Error = resp.Result.Set(ctx, details)
+}
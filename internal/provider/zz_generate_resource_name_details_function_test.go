@@ -0,0 +1,200 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestGenerateResourceNameDetailsFunction_Basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+output "test" {
+  value = provider::resourcenamingtool::generate_resource_name_details([]).name == provider::resourcenamingtool::generate_resource_name([])
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("test", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestGenerateResourceNameDetails(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{
+		"azurerm_resource_group": "{resource_type:short}-{basename}-{env}-{e}-{department:short}-{team:char}-{loc}-{instance+1:%03d}",
+	})
+	config.AdditionalComponents = types.MapValueMust(NewComponentValueType(), map[string]attr.Value{
+		"{team}": testComponentValue(t, "platform", "", ""),
+	})
+	arguments := map[string]map[string]string{
+		"basename":              {"fullname": "payroll"},
+		"environment":           {"fullname": "staging"},
+		"additional_components": {"department.fullname": "finance"},
+	}
+
+	details, diags := generateResourceNameDetails(context.Background(), testNamingParameters(t, arguments), config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := resourceNameDetails{
+		Name:          "rg-payroll-staging-s-finance-p-we-002",
+		ResourceType:  "azurerm_resource_group",
+		Pattern:       "{resource_type:short}-{basename}-{env}-{e}-{department:short}-{team:char}-{loc}-{instance+1:%03d}",
+		PatternKey:    "azurerm_resource_group",
+		PatternSource: patternSourceProvider,
+		Shortening:    []string{},
+		Placeholders: []placeholderResolution{
+			{Placeholder: "{resource_type:short}", Column: 1, Component: "resource_type", Format: "short", Value: "rg", Source: valueSourceProviderDefault},
+			{Placeholder: "{basename}", Column: 23, Component: "basename", Format: "full", Value: "payroll", Source: valueSourceFunctionCall},
+			{Placeholder: "{env}", Column: 34, Component: "environment", Format: "short", Value: "staging", Source: valueSourceFunctionCall,
+				Derivation: "shortcode not set, using the fullname"},
+			{Placeholder: "{e}", Column: 40, Component: "environment", Format: "char", Value: "s", Source: valueSourceFunctionCall,
+				Derivation: "char not set, using the first character of the fullname"},
+			{Placeholder: "{department:short}", Column: 44, Component: "department", Format: "short", Value: "finance", Source: valueSourceFunctionCallAdditionalComponents,
+				Derivation: "shortcode not set, using the fullname"},
+			{Placeholder: "{team:char}", Column: 63, Component: "team", Format: "char", Value: "p", Source: valueSourceProviderAdditionalComponents,
+				Derivation: "char not set, using the first character of the fullname"},
+			{Placeholder: "{loc}", Column: 75, Component: "location", Format: "short", Value: "we", Source: valueSourceProviderDefault,
+				Derivation: "location not set, using region"},
+			{Placeholder: "{instance+1:%03d}", Column: 81, Component: "instance", Format: "%03d", Value: "002", Source: valueSourceProviderDefault,
+				Derivation: "fullname \"00001\" with offset +1 formatted as %03d"},
+		},
+	}
+	if !reflect.DeepEqual(details, expected) {
+		t.Errorf("expected %+v, got %+v", expected, details)
+	}
+}
+
+func TestGenerateResourceNameDetails_Pattern(t *testing.T) {
+	testCases := map[string]struct {
+		patterns      map[string]string
+		patternSets   []string
+		arguments     map[string]map[string]string
+		pattern       string
+		patternKey    string
+		patternSource string
+		patternSet    string
+	}{
+		"built-in pattern": {
+			patternSets:   []string{"azure_caf"},
+			pattern:       builtinPatternSets["azure_caf"].Patterns["azurerm_resource_group"],
+			patternKey:    "azurerm_resource_group",
+			patternSource: patternSourceBuiltin,
+			patternSet:    "azure_caf@" + builtinPatternSets["azure_caf"].Version,
+		},
+		"provider pattern overrides built-in pattern": {
+			patterns:      map[string]string{"azurerm_resource_group": "rg-{basename}"},
+			patternSets:   []string{"azure_caf"},
+			pattern:       "rg-{basename}",
+			patternKey:    "azurerm_resource_group",
+			patternSource: patternSourceProvider,
+		},
+		"provider pattern": {
+			patterns:      map[string]string{"azurerm_resource_group": "rg-{basename}"},
+			pattern:       "rg-{basename}",
+			patternKey:    "azurerm_resource_group",
+			patternSource: patternSourceProvider,
+		},
+		"function call pattern": {
+			patterns: map[string]string{"azurerm_resource_group": "rg-{basename}"},
+			arguments: map[string]map[string]string{
+				"additional_naming_patterns": {"azurerm_resource_group": "rg-{basename}-{env}"},
+			},
+			pattern:       "rg-{basename}-{env}",
+			patternKey:    "azurerm_resource_group",
+			patternSource: patternSourceFunctionCall,
+		},
+		"wildcard pattern": {
+			patterns:      map[string]string{"azurerm_*": "{resource_type:short}-{basename}"},
+			pattern:       "{resource_type:short}-{basename}",
+			patternKey:    "azurerm_*",
+			patternSource: patternSourceProvider,
+		},
+		"default pattern": {
+			patterns:      map[string]string{"default": "{resource_type:short}-{basename}"},
+			pattern:       "{resource_type:short}-{basename}",
+			patternKey:    "default",
+			patternSource: patternSourceProvider,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, testCase.patterns)
+			patternSets := make([]attr.Value, 0, len(testCase.patternSets))
+			for _, name := range testCase.patternSets {
+				patternSets = append(patternSets, types.StringValue(name))
+			}
+			config.BuiltinPatternSets = types.ListValueMust(types.StringType, patternSets)
+			details, diags := generateResourceNameDetails(context.Background(), testNamingParameters(t, testCase.arguments), config)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if details.Pattern != testCase.pattern {
+				t.Errorf("expected pattern %q, got %q", testCase.pattern, details.Pattern)
+			}
+			if details.PatternKey != testCase.patternKey {
+				t.Errorf("expected pattern key %q, got %q", testCase.patternKey, details.PatternKey)
+			}
+			if details.PatternSource != testCase.patternSource {
+				t.Errorf("expected pattern source %q, got %q", testCase.patternSource, details.PatternSource)
+			}
+			if details.PatternSet != testCase.patternSet {
+				t.Errorf("expected pattern set %q, got %q", testCase.patternSet, details.PatternSet)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceNameDetails_Shortening(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{"azurerm_storage_account": "st{basename}{sep}{environment}{hash:4}"})
+	shortening, diags := shorteningToObject(context.Background(), &shorteningStrategy{})
+	if diags.HasError() {
+		t.Fatalf("failed to create shortening strategy: %v", diags)
+	}
+	config.Shortening = shortening
+	arguments := map[string]map[string]string{
+		"resource_type": {"fullname": "azurerm_storage_account"},
+		"basename":      {"fullname": "contosowebapplication", "shortcode": "cwa"},
+	}
+
+	details, diags := generateResourceNameDetails(context.Background(), testNamingParameters(t, arguments), config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// The placeholders describe the abbreviated name, the hash digests the fullname of the components
+	expectedShortening := []string{shorteningStepAbbreviate}
+	expectedPlaceholders := []placeholderResolution{
+		{Placeholder: "{basename}", Column: 3, Component: "basename", Format: "short", Value: "cwa", Source: valueSourceFunctionCall},
+		{Placeholder: "{sep}", Column: 13, Value: "", Source: valueSourceSeparator},
+		{Placeholder: "{environment}", Column: 18, Component: "environment", Format: "short", Value: "prd", Source: valueSourceProviderDefault},
+		{Placeholder: "{hash:4}", Column: 31, Value: shortHash("\x00basename=contosowebapplication\x00environment=production", 4), Source: valueSourceHash,
+			Derivation: "hash of the fullname of basename, environment"},
+	}
+	if details.Name != "stcwaprd"+expectedPlaceholders[3].Value {
+		t.Errorf("expected name %q, got %q", "stcwaprd"+expectedPlaceholders[3].Value, details.Name)
+	}
+	if !reflect.DeepEqual(details.Shortening, expectedShortening) {
+		t.Errorf("expected shortening %v, got %v", expectedShortening, details.Shortening)
+	}
+	if !reflect.DeepEqual(details.Placeholders, expectedPlaceholders) {
+		t.Errorf("expected placeholders %+v, got %+v", expectedPlaceholders, details.Placeholders)
+	}
+}
@@ -5,7 +5,10 @@ package provider
 import (
 	"context"
 	_ "embed" // Import the embed package
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -251,7 +254,65 @@ func (f *GenerateResourceNameFunction) Run(ctx context.Context, req function.Run
 	}
 
 	// Get configuration - use the shared provider config, potentially loading from file
-	var config *resourcenamingtoolProviderModel
+	config := functionProviderConfig(ctx, f.config)
+
+	// Variables to hold diagnostics and result
+	var result string
+	var resultDiags diag.Diagnostics
+
+	// Try to get resource_type from parameters
+	resourceTypeComp, diagResType := resourceParams.GetComponentValue(ctx, "resource_type")
+
+	// If we don't have a valid resource_type in parameters
+	if diagResType.HasError() || resourceTypeComp.IsNull() {
+		// No resource_type provided, we need to add the default one from the provider
+		updatedParams := withComponentParameter(ctx, resourceParams, "resource_type", config.DefaultResourceType)
+
+		// Generate the resource name using the updated parameters
+		result, resultDiags = generateResourceName(ctx, updatedParams, config)
+	} else {
+		// We have a resource_type, use the parameters as provided
+		result, resultDiags = generateResourceName(ctx, resourceParams, config)
+	}
+
+	// Check if there are any error diagnostics
+	if funcErr := functionErrorFromDiagnostics(ctx, resultDiags); funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	// Log the result
+	logDebugWithFields(ctx, "Generated resource name", map[string]interface{}{
+		"result": result,
+	})
+
+	// Set the result
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// withComponentParameter returns a copy of the function parameters in which the component has the given value
+func withComponentParameter(ctx context.Context, params ResourceNamingParametersValue, name string, value ComponentValueObject) ResourceNamingParametersValue {
+	componentAttrs := make(map[string]attr.Value)
+	for k, v := range params.Attributes() {
+		componentAttrs[k] = v
+	}
+	componentAttrs[name] = value
+
+	attrTypes := make(map[string]attr.Type)
+	for k, v := range componentAttrs {
+		attrTypes[k] = v.Type(ctx)
+	}
+
+	newParams, _ := types.ObjectValue(attrTypes, componentAttrs)
+	return ResourceNamingParametersValue{
+		ObjectValue: newParams,
+	}
+}
+
+// functionProviderConfig returns a copy of the provider configuration used by the functions: the shared provider
+// configuration, with the component defaults of the function specific configuration taking precedence
+func functionProviderConfig(ctx context.Context, local *resourcenamingtoolProviderModel) resourcenamingtoolProviderModel {
+	var config resourcenamingtoolProviderModel
 
 	// Try to get the shared provider configuration, which will now check both the
 	// in-memory atomic variable and the file-based storage
@@ -261,150 +322,65 @@ func (f *GenerateResourceNameFunction) Run(ctx context.Context, req function.Run
 		"config": sharedConfig,
 	})
 	if sharedConfig != nil {
-		// Create a deep copy to avoid modifying the shared config
-		config = &resourcenamingtoolProviderModel{}
-		*config = *sharedConfig
+		// Copy the shared config to avoid modifying it
+		config = *sharedConfig
 		logDebug(ctx, "Using shared provider configuration")
 	} else {
-		// No shared config available, create a safe empty config to avoid nil pointer dereference
+		// No shared config available, use an empty config
 		logDebug(ctx, "No shared provider configuration found, creating empty config")
-		config = &resourcenamingtoolProviderModel{}
 	}
 
 	// If function has a local config, use it to override specific values
-	if f.config != nil {
+	if local != nil {
 		logDebug(ctx, "Found function-specific configuration")
 
 		// Only override values that are not null in the local config
-		if !f.config.DefaultResourceType.IsNull() {
-			config.DefaultResourceType = f.config.DefaultResourceType
-		}
-		if !f.config.DefaultResourcePrefix.IsNull() {
-			config.DefaultResourcePrefix = f.config.DefaultResourcePrefix
-		}
-		if !f.config.DefaultBasename.IsNull() {
-			config.DefaultBasename = f.config.DefaultBasename
-		}
-		if !f.config.DefaultEnvironment.IsNull() {
-			config.DefaultEnvironment = f.config.DefaultEnvironment
-		}
-		if !f.config.DefaultRegion.IsNull() {
-			config.DefaultRegion = f.config.DefaultRegion
-		}
-		if !f.config.DefaultInstance.IsNull() {
-			config.DefaultInstance = f.config.DefaultInstance
-		}
-		if !f.config.DefaultOrganization.IsNull() {
-			config.DefaultOrganization = f.config.DefaultOrganization
-		}
-		if !f.config.DefaultProject.IsNull() {
-			config.DefaultProject = f.config.DefaultProject
-		}
-		if !f.config.DefaultBusinessUnit.IsNull() {
-			config.DefaultBusinessUnit = f.config.DefaultBusinessUnit
-		}
-		if !f.config.DefaultCostCenter.IsNull() {
-			config.DefaultCostCenter = f.config.DefaultCostCenter
-		}
-		if !f.config.DefaultApplication.IsNull() {
-			config.DefaultApplication = f.config.DefaultApplication
-		}
-		if !f.config.DefaultWorkload.IsNull() {
-			config.DefaultWorkload = f.config.DefaultWorkload
-		}
-		if !f.config.DefaultSubscription.IsNull() {
-			config.DefaultSubscription = f.config.DefaultSubscription
-		}
-		if !f.config.DefaultLocation.IsNull() {
-			config.DefaultLocation = f.config.DefaultLocation
-		}
-		if !f.config.DefaultDomain.IsNull() {
-			config.DefaultDomain = f.config.DefaultDomain
-		}
-		if !f.config.DefaultCriticality.IsNull() {
-			config.DefaultCriticality = f.config.DefaultCriticality
-		}
-		if !f.config.DefaultInitiative.IsNull() {
-			config.DefaultInitiative = f.config.DefaultInitiative
+		defaults := make(map[string]ComponentValueObject)
+		for _, definition := range componentRegistry {
+			if value := definition.Default(*local); !value.IsNull() {
+				defaults[definition.Name] = value
+			} else if value := definition.Default(config); !value.IsNull() {
+				defaults[definition.Name] = value
+			}
 		}
-		if !f.config.DefaultSolution.IsNull() {
-			config.DefaultSolution = f.config.DefaultSolution
+		componentDefaults, diags := componentDefaultsToMap(defaults)
+		if diags.HasError() {
+			logErrorWithFields(ctx, "Failed to combine the component defaults", map[string]interface{}{
+				"error": diags.Errors()[0].Detail(),
+			})
+		} else {
+			config.ComponentDefaults = componentDefaults
 		}
 	}
+	return config
+}
 
-	// Variables to hold diagnostics and result
-	var result string
-	var resultDiags diag.Diagnostics
-
-	// Try to get resource_type from parameters
-	resourceTypeComp, diagResType := resourceParams.GetComponentValue(ctx, "resource_type")
-
-	// If we don't have a valid resource_type in parameters
-	if diagResType.HasError() || resourceTypeComp.IsNull() {
-		// No resource_type provided, we need to add the default one from the provider
-		componentAttrs := resourceParams.Attributes()
-		if componentAttrs == nil {
-			componentAttrs = make(map[string]attr.Value)
+// functionErrorFromDiagnostics collects the error diagnostics into a single function error, which causes Terraform
+// to fail, or returns nil when there are no errors
+func functionErrorFromDiagnostics(ctx context.Context, diags diag.Diagnostics) *function.FuncError {
+	var errorMessages strings.Builder
+	for _, d := range diags {
+		if d.Severity() != diag.SeverityError {
+			continue
 		}
-
-		// Create a default resource_type component if none was provided
-		componentAttrs["resource_type"] = config.DefaultResourceType
-
-		// Create a new params object with the default resource_type
-		attrTypes := make(map[string]attr.Type)
-		for k, v := range componentAttrs {
-			attrTypes[k] = v.Type(ctx)
+		if errorMessages.Len() > 0 {
+			errorMessages.WriteString("; ")
 		}
-
-		newParams, _ := types.ObjectValue(attrTypes, componentAttrs)
-		updatedParams := ResourceNamingParametersValue{
-			ObjectValue: newParams,
+		errorMessages.WriteString(d.Summary())
+		if d.Detail() != "" {
+			errorMessages.WriteString(": ")
+			errorMessages.WriteString(d.Detail())
 		}
 
-		// Generate the resource name using the updated parameters
-		result, resultDiags = generateResourceName(ctx, updatedParams, *config)
-	} else {
-		// We have a resource_type, use the parameters as provided
-		result, resultDiags = generateResourceName(ctx, resourceParams, *config)
+		logErrorWithFields(ctx, "Function error", map[string]interface{}{
+			"summary": d.Summary(),
+			"detail":  d.Detail(),
+		})
 	}
-
-	// Check if there are any error diagnostics
-	if resultDiags.HasError() {
-		// Collect all error messages into a single error message
-		var errorMessages strings.Builder
-
-		for _, d := range resultDiags {
-			if d.Severity() == diag.SeverityError {
-				if errorMessages.Len() > 0 {
-					errorMessages.WriteString("; ")
-				}
-				errorMessages.WriteString(d.Summary())
-				if d.Detail() != "" {
-					errorMessages.WriteString(": ")
-					errorMessages.WriteString(d.Detail())
-				}
-
-				logErrorWithFields(ctx, "Error generating resource name", map[string]interface{}{
-					"summary": d.Summary(),
-					"detail":  d.Detail(),
-				})
-			}
-		}
-
-		if errorMessages.Len() > 0 {
-			// Create a function error that will cause Terraform to fail
-			resp.Error = function.NewFuncError(errorMessages.String())
-			return
-		}
+	if errorMessages.Len() == 0 {
+		return nil
 	}
-
-	// Log the result
-	logDebugWithFields(ctx, "Generated resource name", map[string]interface{}{
-		"result": result,
-	})
-
-	// Set the result
-	resp.Error = resp.Result.Set(ctx, result)
+	return function.NewFuncError(errorMessages.String())
 }
 
 // setWithNestedMapsToResourceNamingParametersValue converts a set of nested maps to a ResourceNamingParametersValue
@@ -535,6 +511,18 @@ func setWithNestedMapsToResourceNamingParametersValue(ctx context.Context, param
 					}
 				}
 
+				// Special handling for options, these are passed as is
+				if componentName == "options" {
+					if optionsMap, ok := componentValue.(types.Map); ok {
+						logDebugWithFields(ctx, "Found options map", map[string]interface{}{
+							"options": fmt.Sprintf("%v", optionsMap.Elements()),
+						})
+						attrTypes["options"] = types.MapType{ElemType: types.StringType}
+						attributes["options"] = optionsMap
+						continue
+					}
+				}
+
 				// The component value should be a map with attribute values
 				if valueMap, ok := componentValue.(types.Map); ok {
 					logDebugWithFields(ctx, "Component is a map", map[string]interface{}{
@@ -677,10 +665,23 @@ func setWithNestedMapsToResourceNamingParametersValue(ctx context.Context, param
 
 // Generate a resource name by replacing all placeholders in a pattern with actual values
 func generateResourceName(ctx context.Context, params ResourceNamingParametersValue, config resourcenamingtoolProviderModel) (string, diag.Diagnostics) {
+	details, diags := generateResourceNameDetails(ctx, params, config)
+	return details.Name, diags
+}
+
+// generateResourceNameDetails generates a resource name and reports how it was generated: the naming pattern that was
+// used, where it came from, and the value and source of each placeholder of the final name
+func generateResourceNameDetails(ctx context.Context, params ResourceNamingParametersValue, config resourcenamingtoolProviderModel) (resourceNameDetails, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	logDebug(ctx, "Starting generateResourceName function")
 
+	// Decode the configured abbreviations once, they are looked up for every abbreviated placeholder
+	diags.Append(decodeAbbreviations(ctx, &config)...)
+	if diags.HasError() {
+		return resourceNameDetails{}, diags
+	}
+
 	// Show the parameters received
 	logDebugWithFields(ctx, "Received parameters", map[string]interface{}{
 		"length":     len(params.Attributes()),
@@ -696,7 +697,7 @@ func generateResourceName(ctx context.Context, params ResourceNamingParametersVa
 			"error": diagResType.Errors()[0].Summary(),
 		})
 		diags.Append(diagResType...)
-		return "", diags
+		return resourceNameDetails{}, diags
 	}
 
 	resourceTypeFull, diagResTypeFull := resourceTypeComp.GetFullname(ctx)
@@ -714,7 +715,7 @@ func generateResourceName(ctx context.Context, params ResourceNamingParametersVa
 				"default_is_unknown": config.DefaultResourceType.IsUnknown(),
 			})
 			diags.AddError("Missing Required Parameter", "Resource type is required")
-			return "", diags
+			return resourceNameDetails{}, diags
 		}
 		logDebugWithFields(ctx, "Using default resource_type from provider", map[string]interface{}{
 			"resource_type": resourceTypeFull,
@@ -725,14 +726,20 @@ func generateResourceName(ctx context.Context, params ResourceNamingParametersVa
 		})
 	}
 
-	// Build a map of placeholder patterns to actual values
-	placeholders := make(map[string]string)
-	logDebug(ctx, "Building placeholders map")
+	// The components of the function call must be one of the allowed values of the provider
+	if checkDiags := checkCallComponentValues(ctx, params, config); checkDiags.HasError() {
+		diags.Append(checkDiags...)
+		return resourceNameDetails{}, diags
+	}
 
-	// Create a consolidated map of naming patterns - start with built-in patterns
+	// Create a consolidated map of naming patterns - start with the patterns of the selected built-in pattern sets
 	patternElements := make(map[string]attr.Value)
-	for key, value := range builtin_NamingPatterns {
+	// patternSources records where each naming pattern came from, the later sources override the earlier ones
+	patternSources := make(map[string]string)
+	builtinPatterns, builtinPatternSources := builtinNamingPatterns(config)
+	for key, value := range builtinPatterns {
 		patternElements[key] = types.StringValue(value)
+		patternSources[key] = patternSourceBuiltin
 		logDebugWithFields(ctx, "Added built-in pattern", map[string]interface{}{
 			"key":   key,
 			"value": value,
@@ -744,6 +751,7 @@ func generateResourceName(ctx context.Context, params ResourceNamingParametersVa
 		for k, v := range config.AdditionalNamingPatterns.Elements() {
 			if strVal, ok := v.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
 				patternElements[k] = strVal
+				patternSources[k] = patternSourceProvider
 				logDebugWithFields(ctx, "Added pattern from provider config", map[string]interface{}{
 					"key":   k,
 					"value": strVal.ValueString(),
@@ -759,6 +767,7 @@ func generateResourceName(ctx context.Context, params ResourceNamingParametersVa
 		for k, v := range additionalPatterns.Elements() {
 			if strVal, ok := v.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
 				patternElements[k] = strVal
+				patternSources[k] = patternSourceFunctionCall
 				logDebugWithFields(ctx, "Added pattern from function parameters", map[string]interface{}{
 					"key":   k,
 					"value": strVal.ValueString(),
@@ -770,6 +779,7 @@ func generateResourceName(ctx context.Context, params ResourceNamingParametersVa
 		if patternVal, exists := additionalPatterns.Elements()[resourceTypeFull]; exists {
 			if strVal, ok := patternVal.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
 				patternElements[resourceTypeFull] = strVal
+				patternSources[resourceTypeFull] = patternSourceFunctionCall
 				logDebugWithFields(ctx, "Added resource-specific pattern for current resource type", map[string]interface{}{
 					"resource_type": resourceTypeFull,
 					"pattern":       strVal.ValueString(),
@@ -783,10 +793,11 @@ func generateResourceName(ctx context.Context, params ResourceNamingParametersVa
 	}
 
 	// Create the final namingPatterns map
-	namingPatterns, diags := types.MapValue(types.StringType, patternElements)
-	if diags.HasError() {
+	namingPatterns, mapDiags := types.MapValue(types.StringType, patternElements)
+	diags.Append(mapDiags...)
+	if mapDiags.HasError() {
 		logErrorWithFields(ctx, "Failed to create consolidated naming patterns map", map[string]interface{}{
-			"error": diags.Errors()[0].Summary(),
+			"error": mapDiags.Errors()[0].Summary(),
 		})
 	}
 
@@ -797,8 +808,8 @@ func generateResourceName(ctx context.Context, params ResourceNamingParametersVa
 		"length":          len(patternElements),
 	})
 
-	// Get the naming pattern for the resource type
-	patternValue, ok := namingPatterns.Elements()[resourceTypeFull]
+	// Get the naming pattern for the resource type, falling back to the most specific wildcard pattern and the default pattern
+	patternKey, ok := lookupResourceTypeKey(patternElements, resourceTypeFull)
 	if !ok {
 		logErrorWithFields(ctx, "No naming pattern found for resource type", map[string]interface{}{
 			"resource_type": resourceTypeFull,
@@ -811,9 +822,10 @@ func generateResourceName(ctx context.Context, params ResourceNamingParametersVa
 			}(),
 		})
 		diags.AddError("Missing Pattern", fmt.Sprintf("No naming pattern found for resource type: %s", resourceTypeFull))
-		return "", diags
+		return resourceNameDetails{}, diags
 	}
 
+	patternValue := patternElements[patternKey]
 	pattern := ""
 	if str, ok := patternValue.(types.String); ok {
 		pattern = str.ValueString()
@@ -823,497 +835,711 @@ func generateResourceName(ctx context.Context, params ResourceNamingParametersVa
 
 	logDebugWithFields(ctx, "Using naming pattern", map[string]interface{}{
 		"resource_type": resourceTypeFull,
+		"pattern_key":   patternKey,
 		"pattern":       pattern,
 	})
 
-	// Define the component types we'll search for in the pattern
-	componentTypes := []struct {
-		Name      string   // Component name
-		Patterns  []string // Placeholders to search for in pattern
-		ValueType string   // Which value type to use by default: "full", "short", "char"
-	}{
-		// Core components
-		{"resource_type", []string{"{resource_type}", "{resource_type:full}", "{resource_type:short}", "{resource_type:char}"}, "full"},
-		{"resource_prefix", []string{"{resource_prefix}", "{resource_prefix:full}", "{resource_prefix:short}", "{resource_prefix:char}"}, "full"},
-		{"basename", []string{"{basename}", "{basename:full}", "{basename:short}", "{basename:char}"}, "full"},
-		{"environment", []string{"{environment}", "{environment:full}", "{environment:short}", "{environment:char}", "{env}", "{e}"}, "full"},
-		{"region", []string{"{region}", "{region:full}", "{region:short}", "{region:char}", "{location}", "{loc}", "{r}"}, "full"},
-		{"instance", []string{"{instance}", "{instance:full}", "{instance:short}", "{instance:char}", "{inst}", "{i}"}, "full"},
-
-		// Organization components
-		{"organization", []string{"{organization}", "{organization:full}", "{organization:short}", "{organization:char}", "{org}", "{o}"}, "full"},
-		{"business_unit", []string{"{business_unit}", "{business_unit:full}", "{business_unit:short}", "{business_unit:char}", "{bu}"}, "full"},
-		{"cost_center", []string{"{cost_center}", "{cost_center:full}", "{cost_center:short}", "{cost_center:char}", "{cc}"}, "full"},
-		{"project", []string{"{project}", "{project:full}", "{project:short}", "{project:char}", "{proj}", "{p}"}, "full"},
-		{"application", []string{"{application}", "{application:full}", "{application:short}", "{application:char}", "{app}", "{a}"}, "full"},
-		{"workload", []string{"{workload}", "{workload:full}", "{workload:short}", "{workload:char}", "{wl}", "{w}"}, "full"},
-
-		// Provider-specific components
-		{"subscription", []string{"{subscription}", "{subscription:full}", "{subscription:short}", "{subscription:char}", "{sub}", "{s}"}, "full"},
-		{"domain", []string{"{domain}", "{domain:full}", "{domain:short}", "{domain:char}", "{d}"}, "full"},
-		{"criticality", []string{"{criticality}", "{criticality:full}", "{criticality:short}", "{criticality:char}", "{crit}", "{c}"}, "full"},
-
-		// Initiative/solution components
-		{"initiative", []string{"{initiative}", "{initiative:full}", "{initiative:short}", "{initiative:char}", "{init}"}, "full"},
-		{"solution", []string{"{solution}", "{solution:full}", "{solution:short}", "{solution:char}", "{sol}"}, "full"},
-	}
-
-	// For each component, check if it's used in the pattern and add the appropriate value
-	for _, compType := range componentTypes {
-		// For each pattern variation of this component
-		for _, placeholder := range compType.Patterns {
-			if strings.Contains(pattern, placeholder) {
-				logDebugWithFields(ctx, "Found placeholder in pattern", map[string]interface{}{
-					"component":   compType.Name,
-					"placeholder": placeholder,
-					"pattern":     pattern,
-				})
+	// Parse the naming pattern into its literal and placeholder nodes
+	parsedPattern, err := parseNamingPattern(pattern)
+	if err != nil {
+		logErrorWithFields(ctx, "Failed to parse naming pattern", map[string]interface{}{
+			"resource_type": resourceTypeFull,
+			"pattern":       pattern,
+			"error":         err.Error(),
+		})
+		diags.AddError("Invalid Naming Pattern", fmt.Sprintf("Naming pattern %q for resource type %s is invalid: %s", pattern, resourceTypeFull, err.Error()))
+		return resourceNameDetails{}, diags
+	}
+
+	// Expand the pattern fragments and the patterns of other resource types referenced by the pattern
+	references := patternReferences{
+		Fragments: stringMapValues(config.PatternFragments),
+		Patterns: func(resourceType string) (string, bool) {
+			key, ok := matchResourceTypeKey(patternElements, resourceType)
+			if !ok {
+				return "", false
+			}
+			value, ok := patternElements[key].(types.String)
+			return value.ValueString(), ok
+		},
+	}
+	parsedPattern, err = parsedPattern.expand(references, patternReference(patternReferenceNode, resourceTypeFull))
+	if err != nil {
+		logErrorWithFields(ctx, "Failed to expand naming pattern", map[string]interface{}{
+			"resource_type": resourceTypeFull,
+			"pattern":       pattern,
+			"error":         err.Error(),
+		})
+		diags.AddError(patternExpansionSummary(err, "Invalid Naming Pattern"), fmt.Sprintf("Naming pattern %q for resource type %s is invalid: %s", pattern, resourceTypeFull, err.Error()))
+		return resourceNameDetails{}, diags
+	}
 
-				// Determine which format (full, short, char) to use
-				format := compType.ValueType // Default
+	// Collect the additional components passed in the function call, these take precedence over all other values,
+	// and the additional components of the provider, which are used when no other value is available
+	additionalComponents := additionalComponentValues{
+		Call:     getAdditionalComponentGroups(ctx, params),
+		Provider: getProviderAdditionalComponentGroups(ctx, config),
+	}
 
-				// Check for explicit format in placeholder
-				if strings.Contains(placeholder, ":full") {
-					format = "full"
-				} else if strings.Contains(placeholder, ":short") {
-					format = "short"
-				} else if strings.Contains(placeholder, ":char") {
-					format = "char"
-				} else {
-					// For abbreviated patterns, use appropriate format
-					if len(placeholder) <= 5 { // Like {env}, {r}, {i}, etc.
-						format = "short"
-					}
-					if len(placeholder) <= 4 { // Like {e}, {r}, {i}, etc.
-						format = "char"
+	// Log all naming patterns before generating the result
+	logDebugWithFields(ctx, "Final naming patterns for resource name generation", map[string]interface{}{
+		"resource_type":         resourceTypeFull,
+		"naming_patterns_count": len(patternElements),
+		"naming_patterns":       fmt.Sprintf("%v", patternElements),
+	})
+
+	// Collect the options passed in the function call
+	options, err := getFunctionOptions(ctx, params)
+	if err != nil {
+		diags.AddError("Invalid Option", err.Error())
+		return resourceNameDetails{}, diags
+	}
+
+	// Look up the naming rules of the resource type, the sanitize filters are applied to every component value
+	constraint := lookupNamingConstraint(ctx, config, resourceTypeFull)
+	sanitizeFilters, err := constraint.sanitizeFilters()
+	if err != nil {
+		diags.AddError("Invalid Naming Rule", fmt.Sprintf("Naming rules for resource type %s are invalid: %s", resourceTypeFull, err.Error()))
+		return resourceNameDetails{}, diags
+	}
+	separator := lookupSeparator(ctx, config, constraint, options)
+
+	// addRenderError reports an error that occurred while substituting the placeholders of the pattern
+	addRenderError := func(err error) {
+		var valueErr *componentValueError
+		if errors.As(err, &valueErr) {
+			diags.AddError("Invalid Component Value", fmt.Sprintf("Cannot generate a name for resource type %s: %s", resourceTypeFull, valueErr.Error()))
+			return
+		}
+		diags.AddError("Invalid Naming Pattern", fmt.Sprintf("Naming pattern %q for resource type %s is invalid: %s", pattern, resourceTypeFull, err.Error()))
+	}
+
+	// Generate the resource name by substituting all placeholders in a single pass
+	placeholders := make(map[string]string)
+	// resolvedCount is the number of placeholders resolved by the last render
+	resolvedCount := 0
+	// resolutions holds the value and source of each placeholder resolved by the last render
+	var resolutions []placeholderResolution
+	// missingHashInputs holds the component without a value of each unresolved hash placeholder, keyed by column
+	missingHashInputs := make(map[int]string)
+	renderPattern := func(p *namingPattern) (string, []patternNode, error) {
+		resolvedCount = 0
+		resolutions = []placeholderResolution{}
+		missingHashInputs = make(map[int]string)
+		return p.render(func(node patternNode) (string, bool, error) {
+			if isSeparatorPlaceholder(node) {
+				if metacharacter := findPatternMetacharacter(separator); metacharacter != "" {
+					return "", false, &componentValueError{
+						Message: fmt.Sprintf("Placeholder %s at column %d cannot use the separator %q, %q is reserved for naming patterns", node.Text, node.Column, separator, metacharacter),
 					}
 				}
-
-				// Get the component value
-				compValue, diagComp := params.GetComponentValue(ctx, compType.Name)
-				if diagComp.HasError() {
-					logDebugWithFields(ctx, "Error getting component value, will use default", map[string]interface{}{
-						"component": compType.Name,
-						"error":     diagComp.Errors()[0].Summary(),
+				// The separator is always resolved, an empty separator joins the components directly
+				value := applyPatternFilters(applyPatternFilters(separator, node.Filters), sanitizeFilters)
+				placeholders[node.Text] = value
+				resolutions = append(resolutions, newPlaceholderResolution(node, value, valueSource{Source: valueSourceSeparator}))
+				return value, true, nil
+			}
+			var value string
+			var source valueSource
+			if isHashPlaceholder(node) {
+				// The hash digests the fullname of the components, resolved the same way as their placeholders
+				var missing string
+				var ok bool
+				value, missing, ok = hashPlaceholderValue(node, p, config.HashSeed.ValueString(), func(component string) string {
+					componentValue, _, _ := resolvePlaceholder(ctx, patternNode{Kind: placeholderNode, Name: component, Args: []string{"full"}}, params, config, additionalComponents)
+					return componentValue
+				})
+				if !ok {
+					logDebugWithFields(ctx, "Missing hash input for placeholder", map[string]interface{}{
+						"placeholder": node.Text,
+						"column":      node.Column,
+						"component":   missing,
 					})
-					// Skip if error - we'll use provider defaults later
-					continue
+					missingHashInputs[node.Column] = missing
+					return "", false, nil
 				}
-
-				// Get the appropriate representation based on format
-				var value string
-				var localDiag diag.Diagnostics
-
-				switch format {
-				case "full":
-					value, localDiag = compValue.GetFullname(ctx)
-				case "short":
-					value, localDiag = compValue.GetShortcode(ctx)
-					// Fallback to fullname if shortcode is empty
-					if localDiag.HasError() || value == "" {
-						value, _ = compValue.GetFullname(ctx)
-					}
-				case "char":
-					value, localDiag = compValue.GetChar(ctx)
-					// Fallback to first character of fullname if char is empty
-					if localDiag.HasError() || value == "" {
-						fullValue, _ := compValue.GetFullname(ctx)
-						if len(fullValue) > 0 {
-							value = string(fullValue[0])
-						}
-					}
+				source = valueSource{
+					Source:     valueSourceHash,
+					Derivation: "hash of the fullname of " + strings.Join(hashPlaceholderComponents(node, p), ", "),
 				}
-
-				logDebugWithFields(ctx, "Retrieved component value", map[string]interface{}{
-					"component": compType.Name,
-					"format":    format,
-					"value":     value,
-					"has_error": localDiag.HasError(),
+			} else {
+				var err error
+				value, source, err = resolvePlaceholder(ctx, node, params, config, additionalComponents)
+				if err != nil {
+					return "", false, err
+				}
+			}
+			if value == "" {
+				logDebugWithFields(ctx, "Empty value for placeholder", map[string]interface{}{
+					"placeholder": node.Text,
+					"column":      node.Column,
+				})
+				return "", false, nil
+			}
+			// Values are inserted as literals, values that contain pattern syntax are rejected rather than escaped
+			if metacharacter := findPatternMetacharacter(value); metacharacter != "" {
+				return "", false, &componentValueError{
+					Message: fmt.Sprintf("Placeholder %s at column %d cannot use the value %q, %q is reserved for naming patterns", node.Text, node.Column, value, metacharacter),
+				}
+			}
+			if config.Transliterate.ValueBool() {
+				value = transliterateASCII(value)
+			}
+			value = applyPatternFilters(applyPatternFilters(value, node.Filters), sanitizeFilters)
+			if value == "" {
+				logDebugWithFields(ctx, "Empty value for placeholder after sanitization", map[string]interface{}{
+					"placeholder": node.Text,
+					"column":      node.Column,
 				})
+				return "", false, nil
+			}
+			placeholders[node.Text] = value
+			resolutions = append(resolutions, newPlaceholderResolution(node, value, source))
+			resolvedCount++
+			logDebugWithFields(ctx, "Resolved placeholder", map[string]interface{}{
+				"placeholder": node.Text,
+				"column":      node.Column,
+				"value":       value,
+			})
+			return value, true, nil
+		})
+	}
+	result, unresolved, err := renderPattern(parsedPattern)
+	if err != nil {
+		logErrorWithFields(ctx, "Failed to resolve naming pattern", map[string]interface{}{
+			"resource_type": resourceTypeFull,
+			"pattern":       pattern,
+			"error":         err.Error(),
+		})
+		addRenderError(err)
+		return resourceNameDetails{}, diags
+	}
 
-				// Use default from provider config if value is empty
-				if value == "" {
-					logDebugWithFields(ctx, "Component value is empty, trying provider default", map[string]interface{}{
-						"component": compType.Name,
-					})
+	logInfoWithFields(ctx, "Generated resource name", map[string]interface{}{
+		"resource_type":     resourceTypeFull,
+		"pattern_used":      pattern,
+		"placeholders_used": fmt.Sprintf("%v", placeholders),
+		"result":            result,
+	})
 
-					var defaultValue string
-					switch compType.Name {
-					case "resource_type":
-						defaultValue, localDiag = config.DefaultResourceType.GetFullname(ctx)
-					case "resource_prefix":
-						defaultValue, localDiag = config.DefaultResourcePrefix.GetFullname(ctx)
-					case "basename":
-						defaultValue, localDiag = config.DefaultBasename.GetFullname(ctx)
-					case "environment":
-						defaultValue, localDiag = config.DefaultEnvironment.GetFullname(ctx)
-					case "region":
-						defaultValue, localDiag = config.DefaultRegion.GetFullname(ctx)
-					case "instance":
-						defaultValue, localDiag = config.DefaultInstance.GetFullname(ctx)
-					case "organization":
-						defaultValue, localDiag = config.DefaultOrganization.GetFullname(ctx)
-					case "business_unit":
-						defaultValue, localDiag = config.DefaultBusinessUnit.GetFullname(ctx)
-					case "cost_center":
-						defaultValue, localDiag = config.DefaultCostCenter.GetFullname(ctx)
-					case "project":
-						defaultValue, localDiag = config.DefaultProject.GetFullname(ctx)
-					case "application":
-						defaultValue, localDiag = config.DefaultApplication.GetFullname(ctx)
-					case "workload":
-						defaultValue, localDiag = config.DefaultWorkload.GetFullname(ctx)
-					case "subscription":
-						defaultValue, localDiag = config.DefaultSubscription.GetFullname(ctx)
-					case "domain":
-						defaultValue, localDiag = config.DefaultDomain.GetFullname(ctx)
-					case "criticality":
-						defaultValue, localDiag = config.DefaultCriticality.GetFullname(ctx)
-					case "initiative":
-						defaultValue, localDiag = config.DefaultInitiative.GetFullname(ctx)
-					case "solution":
-						defaultValue, localDiag = config.DefaultSolution.GetFullname(ctx)
-					}
+	// Verify result and return errors
+	if len(unresolved) > 0 {
+		logErrorWithFields(ctx, "Resource name contains unresolved components", map[string]interface{}{
+			"pattern":               pattern,
+			"unresolved_components": describePlaceholders(unresolved),
+		})
+		detail := fmt.Sprintf("Naming pattern %q for resource type %s contains components without a value: %s",
+			pattern, resourceTypeFull, describePlaceholders(unresolved))
+		// Hash placeholders are unresolved because of one of their inputs, which isn't part of the placeholder text
+		for _, node := range unresolved {
+			if component, ok := missingHashInputs[node.Column]; ok {
+				detail += fmt.Sprintf(". Hash placeholder %s cannot be computed, its input component %s has no value", node.Text, component)
+			}
+		}
+		diags.AddError("Unresolved Components", detail)
+		return resourceNameDetails{}, diags
+	}
+	if result == "" {
+		logError(ctx, "Generated resource name is empty")
+		diags.AddError("Empty Name", "Resource name cannot be empty")
+		return resourceNameDetails{}, diags
+	}
+	// Shorten names that exceed the max_length of the resource type when a shortening strategy is configured
+	// The name and version of the pattern set are reported for built-in patterns, so changes can be traced
+	var patternSet string
+	if patternSources[patternKey] == patternSourceBuiltin {
+		patternSet = builtinPatternSources[patternKey].String()
+	}
+	details := resourceNameDetails{
+		ResourceType:  resourceTypeFull,
+		Pattern:       pattern,
+		PatternKey:    patternKey,
+		PatternSource: patternSources[patternKey],
+		PatternSet:    patternSet,
+		Shortening:    []string{},
+		Placeholders:  resolutions,
+	}
+	if strategy := lookupShorteningStrategy(ctx, config, constraint); strategy != nil {
+		originalResolvedCount := resolvedCount
+		shortened, steps, err := shortenName(parsedPattern, result, *strategy, constraint, func(p *namingPattern) (string, bool, error) {
+			// Candidates that leave components or optional segments unresolved are discarded
+			candidate, candidateUnresolved, err := renderPattern(p)
+			accepted := len(candidateUnresolved) == 0 && resolvedCount >= originalResolvedCount
+			if accepted {
+				details.Placeholders = resolutions
+			}
+			return candidate, accepted, err
+		})
+		if err != nil {
+			addRenderError(err)
+			return resourceNameDetails{}, diags
+		}
+		if len(steps) > 0 {
+			logInfoWithFields(ctx, "Shortened resource name", map[string]interface{}{
+				"resource_type": resourceTypeFull,
+				"original":      result,
+				"result":        shortened,
+				"steps":         strings.Join(steps, ","),
+			})
+			result = shortened
+			details.Shortening = steps
+		}
+	}
 
-					// If there are diagnostics errors, log them but continue
-					if localDiag.HasError() {
-						logDebugWithFields(ctx, "Error getting default value", map[string]interface{}{
-							"component": compType.Name,
-							"error":     localDiag.Errors()[0].Summary(),
-						})
-						continue
-					}
+	violations := constraint.check(result, resourceTypeFull)
+	if len(violations) > 0 {
+		for _, violation := range violations {
+			logErrorWithFields(ctx, "Generated resource name violates a naming rule", map[string]interface{}{
+				"resource_type": resourceTypeFull,
+				"rule":          violation.Rule,
+				"result":        result,
+			})
+			diags.AddError(violation.Summary, violation.Detail)
+		}
+		return resourceNameDetails{}, diags
+	}
 
-					logDebugWithFields(ctx, "Using default value from provider", map[string]interface{}{
-						"component": compType.Name,
-						"value":     defaultValue,
-					})
+	logDebugWithFields(ctx, "Successfully generated resource name", map[string]interface{}{
+		"resource_type": resourceTypeFull,
+		"result":        result,
+	})
+	details.Name = result
+	return details, diags
+}
 
-					// Get the appropriate representation based on format
-					switch format {
-					case "full":
-						value = defaultValue
-					case "short":
-						// If component is not null, try to get shortcode
-						switch compType.Name {
-						case "resource_type":
-							if !config.DefaultResourceType.IsNull() {
-								value, _ = config.DefaultResourceType.GetShortcode(ctx)
-							}
-						case "resource_prefix":
-							if !config.DefaultResourcePrefix.IsNull() {
-								value, _ = config.DefaultResourcePrefix.GetShortcode(ctx)
-							}
-						case "basename":
-							if !config.DefaultBasename.IsNull() {
-								value, _ = config.DefaultBasename.GetShortcode(ctx)
-							}
-						case "environment":
-							if !config.DefaultEnvironment.IsNull() {
-								value, _ = config.DefaultEnvironment.GetShortcode(ctx)
-							}
-						case "region":
-							if !config.DefaultRegion.IsNull() {
-								value, _ = config.DefaultRegion.GetShortcode(ctx)
-							}
-						case "instance":
-							if !config.DefaultInstance.IsNull() {
-								value, _ = config.DefaultInstance.GetShortcode(ctx)
-							}
-						case "organization":
-							if !config.DefaultOrganization.IsNull() {
-								value, _ = config.DefaultOrganization.GetShortcode(ctx)
-							}
-						case "business_unit":
-							if !config.DefaultBusinessUnit.IsNull() {
-								value, _ = config.DefaultBusinessUnit.GetShortcode(ctx)
-							}
-						case "cost_center":
-							if !config.DefaultCostCenter.IsNull() {
-								value, _ = config.DefaultCostCenter.GetShortcode(ctx)
-							}
-						case "project":
-							if !config.DefaultProject.IsNull() {
-								value, _ = config.DefaultProject.GetShortcode(ctx)
-							}
-						case "application":
-							if !config.DefaultApplication.IsNull() {
-								value, _ = config.DefaultApplication.GetShortcode(ctx)
-							}
-						case "workload":
-							if !config.DefaultWorkload.IsNull() {
-								value, _ = config.DefaultWorkload.GetShortcode(ctx)
-							}
-						case "subscription":
-							if !config.DefaultSubscription.IsNull() {
-								value, _ = config.DefaultSubscription.GetShortcode(ctx)
-							}
-						case "domain":
-							if !config.DefaultDomain.IsNull() {
-								value, _ = config.DefaultDomain.GetShortcode(ctx)
-							}
-						case "criticality":
-							if !config.DefaultCriticality.IsNull() {
-								value, _ = config.DefaultCriticality.GetShortcode(ctx)
-							}
-						case "initiative":
-							if !config.DefaultInitiative.IsNull() {
-								value, _ = config.DefaultInitiative.GetShortcode(ctx)
-							}
-						case "solution":
-							if !config.DefaultSolution.IsNull() {
-								value, _ = config.DefaultSolution.GetShortcode(ctx)
-							}
-						}
+// Sources of the values of placeholders, reported by generate_resource_name_details
+const (
+	valueSourceFunctionCall                     = "function_call"
+	valueSourceFunctionCallAdditionalComponents = "function_call_additional_components"
+	valueSourceProviderDefault                  = "provider_default"
+	valueSourceProviderAdditionalComponents     = "provider_additional_components"
+	valueSourceSeparator                        = "separator"
+	valueSourceHash                             = "hash"
+)
 
-						// Fallback to first 3 characters of fullname if shortcode is empty
-						if value == "" {
-							logDebug(ctx, "Shortcode is empty, using maximum first 3 characters of fullname")
-							if len(defaultValue) > 3 {
-								value = defaultValue[:3]
-							} else if len(defaultValue) > 0 {
-								// If defaultValue is less than 3 characters, use it as is
-								value = defaultValue
-							}
-						}
-					case "char":
-						// If component is not null, try to get char
-						switch compType.Name {
-						case "resource_type":
-							if !config.DefaultResourceType.IsNull() {
-								value, _ = config.DefaultResourceType.GetChar(ctx)
-							}
-						case "resource_prefix":
-							if !config.DefaultResourcePrefix.IsNull() {
-								value, _ = config.DefaultResourcePrefix.GetChar(ctx)
-							}
-						case "basename":
-							if !config.DefaultBasename.IsNull() {
-								value, _ = config.DefaultBasename.GetChar(ctx)
-							}
-						case "environment":
-							if !config.DefaultEnvironment.IsNull() {
-								value, _ = config.DefaultEnvironment.GetChar(ctx)
-							}
-						case "region":
-							if !config.DefaultRegion.IsNull() {
-								value, _ = config.DefaultRegion.GetChar(ctx)
-							}
-						case "instance":
-							if !config.DefaultInstance.IsNull() {
-								value, _ = config.DefaultInstance.GetChar(ctx)
-							}
-						case "organization":
-							if !config.DefaultOrganization.IsNull() {
-								value, _ = config.DefaultOrganization.GetChar(ctx)
-							}
-						case "business_unit":
-							if !config.DefaultBusinessUnit.IsNull() {
-								value, _ = config.DefaultBusinessUnit.GetChar(ctx)
-							}
-						case "cost_center":
-							if !config.DefaultCostCenter.IsNull() {
-								value, _ = config.DefaultCostCenter.GetChar(ctx)
-							}
-						case "project":
-							if !config.DefaultProject.IsNull() {
-								value, _ = config.DefaultProject.GetChar(ctx)
-							}
-						case "application":
-							if !config.DefaultApplication.IsNull() {
-								value, _ = config.DefaultApplication.GetChar(ctx)
-							}
-						case "workload":
-							if !config.DefaultWorkload.IsNull() {
-								value, _ = config.DefaultWorkload.GetChar(ctx)
-							}
-						case "subscription":
-							if !config.DefaultSubscription.IsNull() {
-								value, _ = config.DefaultSubscription.GetChar(ctx)
-							}
-						case "domain":
-							if !config.DefaultDomain.IsNull() {
-								value, _ = config.DefaultDomain.GetChar(ctx)
-							}
-						case "criticality":
-							if !config.DefaultCriticality.IsNull() {
-								value, _ = config.DefaultCriticality.GetChar(ctx)
-							}
-						case "initiative":
-							if !config.DefaultInitiative.IsNull() {
-								value, _ = config.DefaultInitiative.GetChar(ctx)
-							}
-						case "solution":
-							if !config.DefaultSolution.IsNull() {
-								value, _ = config.DefaultSolution.GetChar(ctx)
-							}
-						}
+// valueSource describes where the value of a placeholder came from
+type valueSource struct {
+	// Source is one of the valueSource constants
+	Source string
+	// Derivation describes how the value was derived from the source when it is not used as is, e.g. "first 3
+	// characters of the fullname"
+	Derivation string
+}
 
-						// Fallback to first character of fullname if char is empty
-						if value == "" && len(defaultValue) > 0 {
-							logDebug(ctx, "Char is empty, using first character of fullname")
-							value = string(defaultValue[0])
-						}
-					}
-				}
+// derived returns the source with the derivation added in front of the existing derivation
+func (s valueSource) derived(derivation string) valueSource {
+	if s.Derivation != "" {
+		derivation += "; " + s.Derivation
+	}
+	return valueSource{Source: s.Source, Derivation: derivation}
+}
 
-				// Add to placeholders map
-				if value != "" {
-					placeholders[placeholder] = value
-					logDebugWithFields(ctx, "Added placeholder value", map[string]interface{}{
-						"placeholder": placeholder,
-						"value":       value,
-					})
-				} else {
-					logDebugWithFields(ctx, "Empty value for placeholder", map[string]interface{}{
-						"component":   compType.Name,
-						"placeholder": placeholder,
-					})
-				}
-			}
+// resolvePlaceholder returns the value for a single placeholder node of a naming pattern and where it came from, or
+// an empty string when no value is available
+func resolvePlaceholder(ctx context.Context, node patternNode, params ResourceNamingParametersValue, config resourcenamingtoolProviderModel, additionalComponents additionalComponentValues) (string, valueSource, error) {
+	componentName, format, isBuiltin := lookupComponentPlaceholder(node.Name)
+	if !isBuiltin {
+		// Not a built-in component, it can only be resolved through additional_components
+		componentName = node.Name
+		format = "full"
+	}
+
+	// Check for explicit format in placeholder
+	if len(node.Args) > 0 {
+		format = node.Args[0]
+	}
+
+	// Numeric formats and offsets are applied to the fullname of the component
+	if isNumericPlaceholderFormat(format) || node.Offset != 0 {
+		value, source, err := resolvePlaceholder(ctx, patternNode{Kind: placeholderNode, Name: componentName, Args: []string{"full"}}, params, config, additionalComponents)
+		if err != nil || value == "" {
+			return "", source, err
 		}
+		formatted, err := formatNumericComponent(node, componentName, value, format)
+		return formatted, source.derived(describeNumericFormat(node, value, format)), err
 	}
 
-	// Process additional components from the additional_components map
-	if attrs, ok := params.Attributes()["additional_components"]; ok && !attrs.IsNull() && !attrs.IsUnknown() {
-		logDebugWithFields(ctx, "Processing additional_components for placeholders", map[string]interface{}{
-			"additional_components": attrs.String(),
+	// Values from the additional_components of the function call take precedence over the built-in components
+	if value := additionalComponents.Call[componentName][componentAttributeForFormat(format)]; value != "" {
+		logDebugWithFields(ctx, "Using value from additional_components", map[string]interface{}{
+			"component": componentName,
+			"format":    format,
+			"value":     value,
 		})
+		return value, valueSource{Source: valueSourceFunctionCallAdditionalComponents}, nil
+	}
 
-		// If it's a map, extract the components
-		if additionalMap, ok := attrs.(types.Map); ok {
-			// Create a map to group attributes by component name
-			componentGroups := make(map[string]map[string]string)
-
-			// Process each flattened entry in the additional_components map
-			for key, val := range additionalMap.Elements() {
-				// Parse the dotted key to extract component name and attribute
-				parts := strings.Split(key, ".")
-				if len(parts) == 2 {
-					componentName := parts[0]
-					attrName := parts[1]
-
-					// Initialize the component group if it doesn't exist
-					if _, exists := componentGroups[componentName]; !exists {
-						componentGroups[componentName] = make(map[string]string)
-					}
-
-					// Add the value to the component group
-					if strVal, ok := val.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
-						componentGroups[componentName][attrName] = strVal.ValueString()
-					}
-				}
+	var value string
+	var source valueSource
+	if isBuiltin {
+		value, source = resolveComponentValue(ctx, params, config, componentName, format)
+	}
+	if value == "" {
+		value, source = additionalComponents.fallbackValue(ctx, componentName, format)
+	}
+	if value == "" && isBuiltin {
+		// Components such as location use the value of another component when they have none
+		definition, _ := lookupComponentDefinition(componentName)
+		if definition.Fallback != nil {
+			if fallback := definition.Fallback(config); fallback != "" {
+				logDebugWithFields(ctx, "Component is empty, using fallback component", map[string]interface{}{
+					"component": componentName,
+					"fallback":  fallback,
+					"format":    format,
+				})
+				value, source, err := resolvePlaceholder(ctx, patternNode{Kind: placeholderNode, Name: fallback, Args: []string{format}}, params, config, additionalComponents)
+				return value, source.derived(fmt.Sprintf("%s not set, using %s", componentName, fallback)), err
 			}
+		}
+	}
+	return value, source, nil
+}
 
-			// For each component, add placeholders for it
-			for componentName, attrs := range componentGroups {
-				logDebugWithFields(ctx, "Processing additional component", map[string]interface{}{
-					"component":  componentName,
-					"attributes": attrs,
-				})
+// describeNumericFormat describes how a numeric placeholder formats the fullname of its component
+func describeNumericFormat(node patternNode, value, format string) string {
+	if !isNumericPlaceholderFormat(format) {
+		format = "%d"
+	}
+	if node.Offset != 0 {
+		return fmt.Sprintf("fullname %q with offset %+d formatted as %s", value, node.Offset, format)
+	}
+	return fmt.Sprintf("fullname %q formatted as %s", value, format)
+}
 
-				// Add placeholders for this component with different formats
-				if fullname, ok := attrs["fullname"]; ok && fullname != "" {
-					placeholders["{"+componentName+"}"] = fullname
-					placeholders["{"+componentName+":full}"] = fullname
-					logDebugWithFields(ctx, "Added additional component placeholder", map[string]interface{}{
-						"placeholder": "{" + componentName + "}",
-						"value":       fullname,
-					})
-				}
+// locationFallsBackToRegion reports whether the {location} placeholder uses the region when no location is
+// provided, which is the case unless location_fallback_to_region is set to false
+func locationFallsBackToRegion(config resourcenamingtoolProviderModel) bool {
+	return config.LocationFallbackToRegion.IsNull() || config.LocationFallbackToRegion.IsUnknown() || config.LocationFallbackToRegion.ValueBool()
+}
 
-				if shortcode, ok := attrs["shortcode"]; ok && shortcode != "" {
-					placeholders["{"+componentName+":short}"] = shortcode
-					logDebugWithFields(ctx, "Added additional component placeholder", map[string]interface{}{
-						"placeholder": "{" + componentName + ":short}",
-						"value":       shortcode,
-					})
-				}
+// componentAttributeForFormat returns the component attribute holding the value for a format
+func componentAttributeForFormat(format string) string {
+	switch format {
+	case "short":
+		return "shortcode"
+	case "char":
+		return "char"
+	default:
+		return "fullname"
+	}
+}
 
-				if char, ok := attrs["char"]; ok && char != "" {
-					placeholders["{"+componentName+":char}"] = char
-					logDebugWithFields(ctx, "Added additional component placeholder", map[string]interface{}{
-						"placeholder": "{" + componentName + ":char}",
-						"value":       char,
-					})
-				}
+// resolveComponentValue resolves the value of a built-in component in the requested format and where it came from,
+// using the function parameters first and falling back to the provider defaults
+func resolveComponentValue(ctx context.Context, params ResourceNamingParametersValue, config resourcenamingtoolProviderModel, componentName, format string) (string, valueSource) {
+	// Get the component value
+	compValue, diagComp := params.GetComponentValue(ctx, componentName)
+	if diagComp.HasError() {
+		logDebugWithFields(ctx, "Error getting component value, will use default", map[string]interface{}{
+			"component": componentName,
+			"error":     diagComp.Errors()[0].Summary(),
+		})
+		// Skip if error - the placeholder remains unresolved
+		return "", valueSource{}
+	}
+
+	// Get the appropriate representation based on format
+	var value string
+	var localDiag diag.Diagnostics
+	source := valueSource{Source: valueSourceFunctionCall}
+
+	switch format {
+	case "full":
+		value, localDiag = compValue.GetFullname(ctx)
+	case "short":
+		value, localDiag = compValue.GetShortcode(ctx)
+		// Fallback to the abbreviation of the fullname, or the fullname itself, if shortcode is empty
+		if localDiag.HasError() || value == "" {
+			fullValue, _ := compValue.GetFullname(ctx)
+			value, source.Derivation = abbreviateFullname(ctx, config, componentName, format, fullValue)
+			if value == "" {
+				value = fullValue
+				source.Derivation = "shortcode not set, using the fullname"
+			}
+		}
+	case "char":
+		value, localDiag = compValue.GetChar(ctx)
+		// Fallback to the abbreviation of the fullname, or its first character, if char is empty
+		if localDiag.HasError() || value == "" {
+			fullValue, _ := compValue.GetFullname(ctx)
+			value, source.Derivation = abbreviateFullname(ctx, config, componentName, format, fullValue)
+			if value == "" {
+				value = runePrefix(fullValue, 1)
+				source.Derivation = "char not set, using the first character of the fullname"
 			}
 		}
 	}
 
-	// Log all naming patterns before generating the result
-	logDebugWithFields(ctx, "Final naming patterns for resource name generation", map[string]interface{}{
-		"resource_type":         resourceTypeFull,
-		"naming_patterns_count": len(patternElements),
-		"naming_patterns":       fmt.Sprintf("%v", patternElements),
+	logDebugWithFields(ctx, "Retrieved component value", map[string]interface{}{
+		"component": componentName,
+		"format":    format,
+		"value":     value,
+		"has_error": localDiag.HasError(),
 	})
 
-	// Log all placeholders before generating the result
-	logDebugWithFields(ctx, "Final placeholders for pattern substitution", map[string]interface{}{
-		"resource_type":      resourceTypeFull,
-		"placeholders_count": len(placeholders),
-		"placeholders":       fmt.Sprintf("%v", placeholders),
-	})
+	// Use default from provider config if value is empty
+	if value == "" {
+		logDebugWithFields(ctx, "Component value is empty, trying provider default", map[string]interface{}{
+			"component": componentName,
+		})
+
+		source = valueSource{Source: valueSourceProviderDefault}
+		definition, ok := lookupComponentDefinition(componentName)
+		if !ok {
+			return "", valueSource{}
+		}
+		defaults := definition.Default(config)
 
-	// Generate the resource name by replacing all placeholders
-	result := pattern
-	for placeholder, value := range placeholders {
-		result = strings.ReplaceAll(result, placeholder, value)
-		logDebugWithFields(ctx, "Replaced placeholder", map[string]interface{}{
-			"placeholder":   placeholder,
-			"value":         value,
-			"result_so_far": result,
+		var defaultValue string
+		defaultValue, localDiag = defaults.GetFullname(ctx)
+
+		// If there are diagnostics errors, log them and leave the placeholder unresolved
+		if localDiag.HasError() {
+			logDebugWithFields(ctx, "Error getting default value", map[string]interface{}{
+				"component": componentName,
+				"error":     localDiag.Errors()[0].Summary(),
+			})
+			return "", valueSource{}
+		}
+
+		logDebugWithFields(ctx, "Using default value from provider", map[string]interface{}{
+			"component": componentName,
+			"value":     defaultValue,
 		})
+
+		// Get the appropriate representation based on format
+		switch format {
+		case "full":
+			value = defaultValue
+		case "short":
+			// If component is not null, try to get shortcode
+			if !defaults.IsNull() {
+				value, _ = defaults.GetShortcode(ctx)
+			}
+
+			// Fallback to the abbreviation of the fullname, or its first 3 characters, if shortcode is empty
+			if value == "" {
+				value, source.Derivation = abbreviateFullname(ctx, config, componentName, format, defaultValue)
+			}
+			if value == "" {
+				logDebug(ctx, "Shortcode is empty, using maximum first 3 characters of fullname")
+				value = runePrefix(defaultValue, 3)
+				source.Derivation = "shortcode not set, using the first 3 characters of the fullname"
+			}
+		case "char":
+			// If component is not null, try to get char
+			if !defaults.IsNull() {
+				value, _ = defaults.GetChar(ctx)
+			}
+
+			// Fallback to the abbreviation of the fullname, or its first character, if char is empty
+			if value == "" {
+				value, source.Derivation = abbreviateFullname(ctx, config, componentName, format, defaultValue)
+			}
+			if value == "" && defaultValue != "" {
+				logDebug(ctx, "Char is empty, using first character of fullname")
+				value = runePrefix(defaultValue, 1)
+				source.Derivation = "char not set, using the first character of the fullname"
+			}
+		}
 	}
 
-	logInfoWithFields(ctx, "Generated resource name", map[string]interface{}{
-		"resource_type":     resourceTypeFull,
-		"pattern_used":      pattern,
-		"placeholders_used": fmt.Sprintf("%v", placeholders),
-		"result":            result,
+	return value, source
+}
+
+// componentValueError describes a component value that cannot be used by a placeholder
+type componentValueError struct {
+	Message string
+}
+
+// Error implements the error interface
+func (e *componentValueError) Error() string {
+	return e.Message
+}
+
+// formatNumericComponent adds the offset of the placeholder to the numeric value of a component and formats
+// the result, e.g. "3" becomes "04" for {instance+1:%02d}
+func formatNumericComponent(node patternNode, componentName, value, format string) (string, error) {
+	number, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return "", &componentValueError{
+			Message: fmt.Sprintf("Placeholder %s at column %d requires a whole number, but component %s has the value %q", node.Text, node.Column, componentName, value),
+		}
+	}
+	if !isNumericPlaceholderFormat(format) {
+		format = "%d"
+	}
+	return fmt.Sprintf(format, number+node.Offset), nil
+}
+
+// supportedFunctionOptions lists the options that can be passed in the options entry of the function parameters
+var supportedFunctionOptions = []string{separatorOption}
+
+// getFunctionOptions returns the options passed in the options entry of the function parameters, e.g.
+// { options = { separator = "_" } }. Unsupported options are rejected so that typos don't go unnoticed.
+func getFunctionOptions(ctx context.Context, params ResourceNamingParametersValue) (map[string]string, error) {
+	options := make(map[string]string)
+
+	attrs, ok := params.Attributes()["options"]
+	if !ok || attrs.IsNull() || attrs.IsUnknown() {
+		return options, nil
+	}
+
+	optionsMap, ok := attrs.(types.Map)
+	if !ok {
+		return options, fmt.Errorf("options must be a map of strings, got %T", attrs)
+	}
+	for key, val := range optionsMap.Elements() {
+		if !slices.Contains(supportedFunctionOptions, key) {
+			return options, fmt.Errorf("option %q is not supported, expected one of: %s", key, strings.Join(supportedFunctionOptions, ", "))
+		}
+		if strVal, ok := val.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
+			options[key] = strVal.ValueString()
+		}
+	}
+
+	logDebugWithFields(ctx, "Using function options", map[string]interface{}{
+		"options": fmt.Sprintf("%v", options),
 	})
+	return options, nil
+}
 
-	// Verify result and return errors
-	if strings.Contains(result, "{") {
-		unresolvedComponents := make([]string, 0)
-		for _, part := range strings.Split(result, "{") {
-			if strings.Contains(part, "}") {
-				unresolvedComponents = append(unresolvedComponents, "{"+strings.Split(part, "}")[0]+"}")
-			}
+// additionalComponentValues holds the additional components of the function call and of the provider, grouped by
+// component name and keyed by the fullname, shortcode and char attributes
+type additionalComponentValues struct {
+	Call     map[string]map[string]string
+	Provider map[string]map[string]string
+}
+
+// fallbackValue returns the value of an additional component, and where it came from, when the function call doesn't provide the requested
+// representation. The fullname of the function call takes precedence over the provider, like the function
+// parameters take precedence over the provider defaults, and the shortcode and char are derived from the fullname
+// when they are not set.
+func (c additionalComponentValues) fallbackValue(ctx context.Context, componentName, format string) (string, valueSource) {
+	if fullname := c.Call[componentName]["fullname"]; fullname != "" {
+		var value string
+		source := valueSource{Source: valueSourceFunctionCallAdditionalComponents}
+		switch format {
+		case "short":
+			value = fullname
+			source.Derivation = "shortcode not set, using the fullname"
+		case "char":
+			value = runePrefix(fullname, 1)
+			source.Derivation = "char not set, using the first character of the fullname"
+		}
+		if value != "" {
+			logDebugWithFields(ctx, "Using fullname from additional_components", map[string]interface{}{
+				"component": componentName,
+				"format":    format,
+				"value":     value,
+			})
 		}
+		return value, source
+	}
 
-		logErrorWithFields(ctx, "Resource name contains unresolved components", map[string]interface{}{
-			"result":                result,
-			"unresolved_components": unresolvedComponents,
-		})
-		diags.AddError("Unresolved Components", fmt.Sprintf("Resource name contains unrecognized components: %s", result))
-		return "", diags
+	attrs, ok := c.Provider[componentName]
+	if !ok {
+		return "", valueSource{}
 	}
-	if result == "" {
-		logError(ctx, "Generated resource name is empty")
-		diags.AddError("Empty Name", "Resource name cannot be empty")
-		return "", diags
+	source := valueSource{Source: valueSourceProviderAdditionalComponents}
+	value := attrs[componentAttributeForFormat(format)]
+	fullname := attrs["fullname"]
+	if value == "" && fullname != "" {
+		switch format {
+		case "short":
+			value = runePrefix(fullname, 3)
+			source.Derivation = "shortcode not set, using the first 3 characters of the fullname"
+		case "char":
+			value = runePrefix(fullname, 1)
+			source.Derivation = "char not set, using the first character of the fullname"
+		}
 	}
-	if len(result) > 90 {
-		logErrorWithFields(ctx, "Generated resource name is too long", map[string]interface{}{
-			"length": len(result),
-			"result": result,
-		})
-		diags.AddError("Name Too Long", fmt.Sprintf("Resource name exceeds 90 characters: %s", result))
-		return "", diags
+	logDebugWithFields(ctx, "Using value from provider additional_components", map[string]interface{}{
+		"component": componentName,
+		"format":    format,
+		"value":     value,
+	})
+	return value, source
+}
+
+// getProviderAdditionalComponentGroups groups the additional_components of the provider ("{component}" keys) by
+// component name
+func getProviderAdditionalComponentGroups(ctx context.Context, config resourcenamingtoolProviderModel) map[string]map[string]string {
+	componentGroups := make(map[string]map[string]string)
+	if config.AdditionalComponents.IsNull() || config.AdditionalComponents.IsUnknown() {
+		return componentGroups
 	}
-	if len(result) < 3 {
-		logErrorWithFields(ctx, "Generated resource name is too short", map[string]interface{}{
-			"length": len(result),
-			"result": result,
-		})
-		diags.AddError("Name Too Short", fmt.Sprintf("Resource name must be at least 3 characters long: %s", result))
-		return "", diags
+
+	for key, val := range config.AdditionalComponents.Elements() {
+		componentName := strings.TrimSuffix(strings.TrimPrefix(key, "{"), "}")
+
+		var component ComponentValueObject
+		switch value := val.(type) {
+		case ComponentValueObject:
+			component = value
+		case basetypes.ObjectValue:
+			component = ComponentValueObject{ObjectValue: value}
+		default:
+			logDebugWithFields(ctx, "Skipping provider additional component with unexpected type", map[string]interface{}{
+				"component": componentName,
+				"type":      fmt.Sprintf("%T", val),
+			})
+			continue
+		}
+		if component.IsNull() || component.IsUnknown() {
+			continue
+		}
+
+		fullname, _ := component.GetFullname(ctx)
+		shortcode, _ := component.GetShortcode(ctx)
+		char, _ := component.GetChar(ctx)
+		componentGroups[componentName] = map[string]string{
+			"fullname":  fullname,
+			"shortcode": shortcode,
+			"char":      char,
+		}
 	}
 
-	logDebugWithFields(ctx, "Successfully generated resource name", map[string]interface{}{
-		"resource_type": resourceTypeFull,
-		"result":        result,
+	return componentGroups
+}
+
+// getAdditionalComponentGroups groups the flattened additional_components entries of the function
+// parameters ("component.attribute" keys) by component name
+func getAdditionalComponentGroups(ctx context.Context, params ResourceNamingParametersValue) map[string]map[string]string {
+	// Create a map to group attributes by component name
+	componentGroups := make(map[string]map[string]string)
+
+	attrs, ok := params.Attributes()["additional_components"]
+	if !ok || attrs.IsNull() || attrs.IsUnknown() {
+		return componentGroups
+	}
+
+	logDebugWithFields(ctx, "Processing additional_components for placeholders", map[string]interface{}{
+		"additional_components": attrs.String(),
 	})
-	return result, diags
+
+	// If it's a map, extract the components
+	if additionalMap, ok := attrs.(types.Map); ok {
+		// Process each flattened entry in the additional_components map
+		for key, val := range additionalMap.Elements() {
+			// Parse the dotted key to extract component name and attribute
+			parts := strings.Split(key, ".")
+			if len(parts) == 2 {
+				componentName := parts[0]
+				attrName := parts[1]
+
+				// Initialize the component group if it doesn't exist
+				if _, exists := componentGroups[componentName]; !exists {
+					componentGroups[componentName] = make(map[string]string)
+				}
+
+				// Add the value to the component group
+				if strVal, ok := val.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
+					componentGroups[componentName][attrName] = strVal.ValueString()
+				}
+			}
+		}
+	}
+
+	return componentGroups
 }
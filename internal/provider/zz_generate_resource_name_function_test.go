@@ -3,8 +3,15 @@
 package provider
 
 import (
+	"context"
 	"regexp"
+	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
@@ -122,6 +129,29 @@ output "test" {
 	})
 }
 
+func TestGenerateResourceNameFunction_InvalidPattern(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+output "test" {
+  value = provider::resourcenamingtool::generate_resource_name([{
+	additional_naming_patterns = {
+		"azurerm_resource_group" = "rg-{basename-{region}"
+	}
+	}])
+}
+`,
+				ExpectError: regexp.MustCompile(`syntax error at column 14`),
+			},
+		},
+	})
+}
+
 func TestGenerateResourceNameFunction_Null(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
@@ -140,3 +170,1008 @@ output "test" {
 		},
 	})
 }
+
+// testComponentValue builds a ComponentValueObject for use in unit tests
+func testComponentValue(t *testing.T, fullname, shortcode, char string) ComponentValueObject {
+	t.Helper()
+	component, diags := CreateComponentValueObjectFromParts(context.Background(), fullname, shortcode, char)
+	if diags.HasError() {
+		t.Fatalf("failed to create component value: %v", diags)
+	}
+	return component
+}
+
+// testNamingParameters converts function arguments, as Terraform passes them to the function, into a ResourceNamingParametersValue
+func testNamingParameters(t *testing.T, arguments map[string]map[string]string) ResourceNamingParametersValue {
+	t.Helper()
+	elements := make(map[string]attr.Value)
+	for name, values := range arguments {
+		mapValues := make(map[string]attr.Value)
+		for key, value := range values {
+			mapValues[key] = types.StringValue(value)
+		}
+		elements[name] = types.MapValueMust(types.StringType, mapValues)
+	}
+	elementType := types.MapType{ElemType: types.MapType{ElemType: types.StringType}}
+	set := types.SetValueMust(elementType, []attr.Value{types.MapValueMust(elementType.ElemType, elements)})
+
+	params, err := setWithNestedMapsToResourceNamingParametersValue(context.Background(), set)
+	if err != nil {
+		t.Fatalf("failed to convert parameters: %s", err)
+	}
+	return params
+}
+
+// testNamingConfig returns a provider configuration equivalent to providerConfig with the given naming patterns
+func testNamingConfig(t *testing.T, patterns map[string]string) resourcenamingtoolProviderModel {
+	t.Helper()
+	patternValues := make(map[string]attr.Value)
+	for key, value := range patterns {
+		patternValues[key] = types.StringValue(value)
+	}
+	return resourcenamingtoolProviderModel{
+		DefaultResourceType:      testComponentValue(t, "azurerm_resource_group", "rg", "r"),
+		DefaultEnvironment:       testComponentValue(t, "production", "prd", "p"),
+		DefaultInstance:          testComponentValue(t, "00001", "001", "1"),
+		DefaultBasename:          testComponentValue(t, "example", "ex", "e"),
+		DefaultSubscription:      testComponentValue(t, "prod-01", "p01", "p"),
+		DefaultRegion:            testComponentValue(t, "westeurope", "we", "w"),
+		AdditionalComponents:     types.MapNull(NewComponentValueType()),
+		AdditionalNamingPatterns: types.MapValueMust(types.StringType, patternValues),
+	}
+}
+
+// testNamingRules converts naming rules into the naming_rules provider attribute
+func testNamingRules(t *testing.T, rules map[string]namingRule) types.Map {
+	t.Helper()
+	rulesMap, diags := namingRulesToMap(context.Background(), rules)
+	if diags.HasError() {
+		t.Fatalf("failed to create naming rules: %v", diags)
+	}
+	return rulesMap
+}
+
+// testGenerateResourceName generates a name for the given pattern of azurerm_resource_group
+func testGenerateResourceName(t *testing.T, pattern string, arguments map[string]map[string]string) (string, diag.Diagnostics) {
+	t.Helper()
+	return testGenerateResourceNameWithRules(t, pattern, arguments, nil)
+}
+
+// testGenerateResourceNameWithRules generates a name for the given pattern of azurerm_resource_group using the given naming rules
+func testGenerateResourceNameWithRules(t *testing.T, pattern string, arguments map[string]map[string]string, rules map[string]namingRule) (string, diag.Diagnostics) {
+	t.Helper()
+	config := testNamingConfig(t, map[string]string{"azurerm_resource_group": pattern})
+	config.NamingRules = testNamingRules(t, rules)
+	return generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+}
+
+func TestGenerateResourceName_Placeholders(t *testing.T) {
+	testCases := map[string]struct {
+		pattern   string
+		arguments map[string]map[string]string
+		rules     map[string]namingRule
+		expected  string
+	}{
+		"formats": {
+			pattern:  "rg-{basename}-{environment:short}-{region:char}-{instance:full}",
+			expected: "rg-example-prd-w-00001",
+		},
+		"aliases": {
+			pattern:  "{env}{e}-{inst}-{r}",
+			expected: "prdp-00001-w",
+		},
+		"escaped braces": {
+			pattern: "{{{basename}}}",
+			rules: map[string]namingRule{
+				"azurerm_resource_group": {AllowedCharacters: stringPointer(`a-z{}`), TrailingCharacters: stringPointer(`a-z{}`)},
+			},
+			expected: "{example}",
+		},
+		"additional components": {
+			pattern: "{basename}-{department:short}-{region:short}",
+			arguments: map[string]map[string]string{
+				"additional_components": {
+					"department.fullname":  "engineering",
+					"department.shortcode": "eng",
+					"region.shortcode":     "gwc",
+				},
+			},
+			expected: "example-eng-gwc",
+		},
+		"optional segment dropped": {
+			pattern:  "rg-{basename}[-{department}]-{region:short}",
+			expected: "rg-example-we",
+		},
+		"optional segment kept": {
+			pattern: "rg-{basename}[-{department}]-{region:short}",
+			arguments: map[string]map[string]string{
+				"additional_components": {"department.fullname": "finance"},
+			},
+			expected: "rg-example-finance-we",
+		},
+		"filters": {
+			pattern:  "{resource_type:short|upper}-{basename|trunc:3}-{instance|replace:0:|pad:3}",
+			expected: "RG-exa-001",
+		},
+		"optional segment emptied by a filter": {
+			pattern:  "rg-{basename}-[{environment:short|replace:prd:}]-{region:short}",
+			expected: "rg-example-we",
+		},
+		"numeric instance": {
+			pattern:  "vm-{basename}-{instance:%03d}",
+			expected: "vm-example-001",
+		},
+		"numeric instance offset": {
+			pattern: "vm-{basename}-{instance+1:%02d}-{i-1}",
+			arguments: map[string]map[string]string{
+				"instance": {"fullname": "7"},
+			},
+			expected: "vm-example-08-6",
+		},
+		"hash": {
+			pattern:  "rg-{basename}-{hash:6}",
+			expected: "rg-example-" + shortHash("\x00basename=example", 6),
+		},
+		"hash of components": {
+			pattern:  "rg-{basename}-{hash:8:env,region|upper}",
+			expected: "rg-example-" + strings.ToUpper(shortHash("\x00environment=production\x00region=westeurope", 8)),
+		},
+		"parameters": {
+			pattern: "{basename}-{environment:short}",
+			arguments: map[string]map[string]string{
+				"environment": {"fullname": "development", "shortcode": "dev"},
+			},
+			expected: "example-dev",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, diags := testGenerateResourceNameWithRules(t, testCase.pattern, testCase.arguments, testCase.rules)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		pattern   string
+		arguments map[string]map[string]string
+		rules     map[string]namingRule
+		summary   string
+		detail    string
+	}{
+		"unresolved component": {
+			pattern: "rg-{basename}-{department}-{organization:short}",
+			summary: "Unresolved Components",
+			detail:  "{department} (column 15), {organization:short} (column 28)",
+		},
+		"component emptied by a filter": {
+			pattern: "rg-{basename}-{environment:short|replace:prd:}-{region:short}",
+			summary: "Unresolved Components",
+			detail:  "{environment:short|replace:prd:} (column 15)",
+		},
+		"syntax error": {
+			pattern: "rg-{basename-{region}",
+			summary: "Invalid Naming Pattern",
+			detail:  "syntax error at column 14: unexpected '{' inside placeholder",
+		},
+		"unresolved hash component": {
+			pattern: "rg-{basename}-{hash:6:department}",
+			summary: "Unresolved Components",
+			detail:  "{hash:6:department} (column 15). Hash placeholder {hash:6:department} cannot be computed, its input component department has no value",
+		},
+		"unresolved implicit hash component": {
+			pattern: "rg-{basename}[-{workload}]-{hash:6}",
+			summary: "Unresolved Components",
+			detail:  "Hash placeholder {hash:6} cannot be computed, its input component workload has no value",
+		},
+		"non-numeric instance": {
+			pattern: "vm-{basename}-{instance:%03d}",
+			arguments: map[string]map[string]string{
+				"instance": {"fullname": "primary"},
+			},
+			summary: "Invalid Component Value",
+			detail:  "Placeholder {instance:%03d} at column 15 requires a whole number",
+		},
+		"value with a placeholder": {
+			pattern: "rg-{basename}-{region}",
+			arguments: map[string]map[string]string{
+				"basename": {"fullname": "{region}"},
+			},
+			summary: "Invalid Component Value",
+			detail:  "Placeholder {basename} at column 4 cannot use the value \"{region}\", \"{\" is reserved for naming patterns",
+		},
+		"shortcode with an optional segment": {
+			pattern: "rg-{basename:short}-{region}",
+			arguments: map[string]map[string]string{
+				"basename": {"fullname": "webapp", "shortcode": "web]"},
+			},
+			summary: "Invalid Component Value",
+			detail:  "cannot use the value \"web]\", \"]\" is reserved for naming patterns",
+		},
+		"additional component with a placeholder": {
+			pattern: "rg-{basename}-{department}",
+			arguments: map[string]map[string]string{
+				"additional_components": {"department.fullname": "eng{instance}"},
+			},
+			summary: "Invalid Component Value",
+			detail:  "Placeholder {department} at column 15 cannot use the value \"eng{instance}\"",
+		},
+		"separator option with a placeholder": {
+			pattern: "rg{sep}{basename}",
+			arguments: map[string]map[string]string{
+				"options": {"separator": "{"},
+			},
+			summary: "Invalid Component Value",
+			detail:  "Placeholder {sep} at column 3 cannot use the separator \"{\"",
+		},
+		"built-in naming rule": {
+			pattern: "rg {basename}",
+			summary: "Invalid Characters",
+			detail:  "allowed_characters rule [a-zA-Z0-9._()-] for resource type azurerm_resource_group",
+		},
+		"configured naming rule": {
+			pattern: "rg-{basename}-{region}",
+			rules: map[string]namingRule{
+				"azurerm_resource_group": {MaxLength: intPointer(12)},
+			},
+			summary: "Name Too Long",
+			detail:  "is 21 characters long, exceeding the max_length of 12",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, diags := testGenerateResourceNameWithRules(t, testCase.pattern, testCase.arguments, testCase.rules)
+			if !diags.HasError() {
+				t.Fatalf("expected an error")
+			}
+			if diags.Errors()[0].Summary() != testCase.summary {
+				t.Errorf("expected summary %q, got %q", testCase.summary, diags.Errors()[0].Summary())
+			}
+			if !strings.Contains(diags.Errors()[0].Detail(), testCase.detail) {
+				t.Errorf("expected detail containing %q, got %q", testCase.detail, diags.Errors()[0].Detail())
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_Shortening(t *testing.T) {
+	arguments := map[string]map[string]string{
+		"resource_type": {"fullname": "azurerm_storage_account"},
+		"basename":      {"fullname": "contosowebapplication", "shortcode": "cwa"},
+	}
+	original := "stcontosowebapplicationproductionwesteurope001"
+
+	testCases := map[string]struct {
+		shortening *shorteningStrategy
+		rules      map[string]namingRule
+		expected   string
+		summary    string
+	}{
+		"disabled": {
+			summary: "Name Too Long",
+		},
+		"provider wide strategy": {
+			shortening: &shorteningStrategy{},
+			expected:   "stcwaprdwe001",
+		},
+		"resource type strategy": {
+			shortening: &shorteningStrategy{},
+			rules: map[string]namingRule{
+				"azurerm_storage_account": {Shortening: &shorteningStrategy{Steps: []string{shorteningStepTruncate}}},
+			},
+			expected: "stcontosowebapplicat" + shortHash(original, 4),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{"azurerm_storage_account": "st{basename}{environment}{region}{instance:short}"})
+			config.NamingRules = testNamingRules(t, testCase.rules)
+			shortening, diags := shorteningToObject(context.Background(), testCase.shortening)
+			if diags.HasError() {
+				t.Fatalf("failed to create shortening strategy: %v", diags)
+			}
+			config.Shortening = shortening
+
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+			if testCase.summary != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != testCase.summary {
+					t.Fatalf("expected error %q, got %v", testCase.summary, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_HashSeed(t *testing.T) {
+	generate := func(seed string) string {
+		config := testNamingConfig(t, map[string]string{"azurerm_storage_account": "st{basename}{hash:6}"})
+		config.HashSeed = types.StringValue(seed)
+		result, diags := generateResourceName(context.Background(), testNamingParameters(t, map[string]map[string]string{
+			"resource_type": {"fullname": "azurerm_storage_account"},
+		}), config)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return result
+	}
+
+	first := generate("contoso")
+	if first != "stexample"+shortHash("contoso\x00basename=example", 6) {
+		t.Errorf("unexpected hashed name %q", first)
+	}
+	if second := generate("contoso"); second != first {
+		t.Errorf("expected the same seed to produce the same name, got %q and %q", first, second)
+	}
+	if other := generate("fabrikam"); other == first {
+		t.Errorf("expected a different seed to produce a different name, both produced %q", first)
+	}
+}
+
+func TestGenerateResourceName_Sanitize(t *testing.T) {
+	testCases := map[string]struct {
+		resourceType string
+		pattern      string
+		basename     string
+		rules        map[string]namingRule
+		expected     string
+		summary      string
+	}{
+		"storage account": {
+			resourceType: "azurerm_storage_account",
+			pattern:      "st{basename}{environment:char}{region:char}{instance:short}",
+			basename:     "My-App",
+			expected:     "stmyapppw001",
+		},
+		"container registry": {
+			resourceType: "azurerm_container_registry",
+			pattern:      "acr{basename}{environment:short}",
+			basename:     "my-app.v2",
+			expected:     "acrmyappv2prd",
+		},
+		"gcp": {
+			resourceType: "google_compute_instance",
+			pattern:      "vm-{basename}-{environment:short}",
+			basename:     "Web_App",
+			expected:     "vm-web-app-prd",
+		},
+		"s3 bucket": {
+			resourceType: "aws_s3_bucket",
+			pattern:      "{basename}-{environment}",
+			basename:     "Data_Lake",
+			expected:     "data-lake-production",
+		},
+		"configured": {
+			resourceType: "azurerm_key_vault",
+			pattern:      "kv-{basename}-{environment:short}",
+			basename:     "Payroll_App",
+			rules: map[string]namingRule{
+				"azurerm_key_vault": {Sanitize: []string{"lower", "replace:_:"}},
+			},
+			expected: "kv-payrollapp-prd",
+		},
+		"disabled": {
+			resourceType: "azurerm_storage_account",
+			pattern:      "st{basename}{environment:char}",
+			basename:     "my-app",
+			rules: map[string]namingRule{
+				"azurerm_storage_account": {Sanitize: []string{}},
+			},
+			summary: "Invalid Characters",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{testCase.resourceType: testCase.pattern})
+			config.NamingRules = testNamingRules(t, testCase.rules)
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, map[string]map[string]string{
+				"resource_type": {"fullname": testCase.resourceType},
+				"basename":      {"fullname": testCase.basename},
+			}), config)
+			if testCase.summary != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != testCase.summary {
+					t.Fatalf("expected error %q, got %v", testCase.summary, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_Separator(t *testing.T) {
+	testCases := map[string]struct {
+		resourceType     string
+		pattern          string
+		defaultSeparator *string
+		rules            map[string]namingRule
+		options          map[string]string
+		expected         string
+		summary          string
+	}{
+		"default": {
+			resourceType: "azurerm_resource_group",
+			pattern:      "rg{sep}{basename}{sep}{environment:short}",
+			expected:     "rg-example-prd",
+		},
+		"provider": {
+			resourceType:     "azurerm_resource_group",
+			pattern:          "rg{sep}{basename}{sep}{environment:short}",
+			defaultSeparator: stringPointer("_"),
+			expected:         "rg_example_prd",
+		},
+		"resource type": {
+			resourceType:     "azurerm_mssql_database",
+			pattern:          "sqldb{sep}{basename}{sep}{environment:short}",
+			defaultSeparator: stringPointer("."),
+			rules: map[string]namingRule{
+				"azurerm_mssql_database": {Separator: stringPointer("_")},
+			},
+			expected: "sqldb_example_prd",
+		},
+		"empty": {
+			resourceType: "azurerm_resource_group",
+			pattern:      "rg{sep}{basename}{sep}{environment:short}",
+			rules: map[string]namingRule{
+				"azurerm_resource_group": {Separator: stringPointer("")},
+			},
+			expected: "rgexampleprd",
+		},
+		"function call": {
+			resourceType: "azurerm_resource_group",
+			pattern:      "rg{sep}{basename}{sep}{environment:short}",
+			rules: map[string]namingRule{
+				"azurerm_resource_group": {Separator: stringPointer("")},
+			},
+			options:  map[string]string{"separator": "."},
+			expected: "rg.example.prd",
+		},
+		"optional segment": {
+			resourceType: "azurerm_resource_group",
+			pattern:      "rg{sep}{basename}[{sep}{project}]{sep}{environment:short}",
+			expected:     "rg-example-prd",
+		},
+		"filters": {
+			resourceType:     "azurerm_resource_group",
+			pattern:          "rg{sep|replace:_:--}{basename}",
+			defaultSeparator: stringPointer("_"),
+			expected:         "rg--example",
+		},
+		"sanitized": {
+			resourceType: "azurerm_storage_account",
+			pattern:      "st{sep}{basename}{sep}{environment:char}",
+			expected:     "stexamplep",
+		},
+		"unsupported option": {
+			resourceType: "azurerm_resource_group",
+			pattern:      "rg{sep}{basename}",
+			options:      map[string]string{"seperator": "_"},
+			summary:      "Invalid Option",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{testCase.resourceType: testCase.pattern})
+			config.NamingRules = testNamingRules(t, testCase.rules)
+			config.DefaultSeparator = types.StringPointerValue(testCase.defaultSeparator)
+			arguments := map[string]map[string]string{
+				"resource_type": {"fullname": testCase.resourceType},
+			}
+			if testCase.options != nil {
+				arguments["options"] = testCase.options
+			}
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+			if testCase.summary != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != testCase.summary {
+					t.Fatalf("expected error %q, got %v", testCase.summary, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+// stringPointer returns a pointer to value, for optional string settings in test cases
+func stringPointer(value string) *string {
+	return &value
+}
+
+// intPointer returns a pointer to value, for optional integer settings in test cases
+func intPointer(value int) *int {
+	return &value
+}
+
+func TestGenerateResourceName_PatternFragments(t *testing.T) {
+	testCases := map[string]struct {
+		resourceType string
+		arguments    map[string]map[string]string
+		expected     string
+		summary      string
+		detail       string
+	}{
+		"fragment": {
+			resourceType: "azurerm_resource_group",
+			expected:     "rg-example-prd-we",
+		},
+		"pattern reference": {
+			resourceType: "azurerm_subnet",
+			expected:     "vnet-example-prd-we-snet",
+		},
+		"function call pattern": {
+			resourceType: "azurerm_network_interface",
+			arguments: map[string]map[string]string{
+				"additional_naming_patterns": {"azurerm_network_interface": "nic-{basename}-{@suffix}"},
+			},
+			expected: "nic-example-prd-we",
+		},
+		"function call pattern reference": {
+			resourceType: "azurerm_subnet",
+			arguments: map[string]map[string]string{
+				"additional_naming_patterns": {"azurerm_virtual_network": "vnet-{basename}"},
+			},
+			expected: "vnet-example-snet",
+		},
+		"cycle": {
+			resourceType: "azurerm_route_table",
+			summary:      "Invalid Naming Pattern",
+			detail:       "pattern reference cycle detected: {@@azurerm_route_table} -> {@@azurerm_route_table}",
+		},
+		"undefined fragment": {
+			resourceType: "azurerm_public_ip",
+			summary:      "Invalid Naming Pattern",
+			detail:       "pattern fragment \"prefix\" referenced at column 1 is not defined in pattern_fragments",
+		},
+		"misspelled pattern reference": {
+			resourceType: "azurerm_network_security_group",
+			summary:      "Unknown Pattern Reference",
+			detail:       "no naming pattern found for resource type azurerm_virtual_netwrok referenced at column 5",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{
+				"azurerm_resource_group":  "rg-{basename}-{@suffix}",
+				"azurerm_virtual_network": "vnet-{basename}-{@suffix}",
+				"azurerm_subnet":          "{@@azurerm_virtual_network}-snet",
+				"azurerm_route_table":     "rt-{@@azurerm_route_table}",
+				"azurerm_public_ip":       "{@prefix}-pip",
+				// The misspelled reference must not fall back to the default pattern
+				"azurerm_network_security_group": "nsg-{@@azurerm_virtual_netwrok}",
+				"default":                        "{basename}",
+			})
+			config.PatternFragments = types.MapValueMust(types.StringType, map[string]attr.Value{
+				"suffix": types.StringValue("{environment:short}-{region:short}"),
+			})
+			arguments := map[string]map[string]string{
+				"resource_type": {"fullname": testCase.resourceType},
+			}
+			for key, value := range testCase.arguments {
+				arguments[key] = value
+			}
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+			if testCase.summary != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != testCase.summary || !strings.Contains(diags.Errors()[0].Detail(), testCase.detail) {
+					t.Fatalf("expected error %q containing %q, got %v", testCase.summary, testCase.detail, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_PatternLookup(t *testing.T) {
+	patterns := map[string]string{
+		"azurerm_mssql_database": "sqldb-{basename}",
+		"azurerm_*_database":     "db-{basename}-{environment:short}",
+		"aws_*":                  "{basename}-{environment:short}-aws",
+		"default":                "{basename}-{environment:short}",
+	}
+	testCases := map[string]struct {
+		resourceType string
+		arguments    map[string]map[string]string
+		patterns     map[string]string
+		expected     string
+		summary      string
+	}{
+		"exact":    {resourceType: "azurerm_mssql_database", patterns: patterns, expected: "sqldb-example"},
+		"wildcard": {resourceType: "azurerm_postgresql_database", patterns: patterns, expected: "db-example-prd"},
+		"prefix":   {resourceType: "aws_s3_bucket", patterns: patterns, expected: "example-prd-aws"},
+		"default":  {resourceType: "oci_core_instance", patterns: patterns, expected: "example-prd"},
+		"function call wildcard": {
+			resourceType: "aws_s3_bucket",
+			arguments: map[string]map[string]string{
+				"additional_naming_patterns": {"aws_s3_*": "s3-{basename}"},
+			},
+			patterns: patterns,
+			expected: "s3-example",
+		},
+		"missing": {
+			resourceType: "oci_core_instance",
+			patterns:     map[string]string{"aws_*": "{basename}-aws"},
+			summary:      "Missing Pattern",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, testCase.patterns)
+			arguments := map[string]map[string]string{
+				"resource_type": {"fullname": testCase.resourceType},
+			}
+			for key, value := range testCase.arguments {
+				arguments[key] = value
+			}
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+			if testCase.summary != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != testCase.summary {
+					t.Fatalf("expected error %q, got %v", testCase.summary, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_Location(t *testing.T) {
+	testCases := map[string]struct {
+		pattern         string
+		arguments       map[string]map[string]string
+		defaultLocation bool
+		fallback        types.Bool
+		expected        string
+		summary         string
+	}{
+		"function call location": {
+			pattern: "{basename}-{location}-{region:short}",
+			arguments: map[string]map[string]string{
+				"location": {"fullname": "westus2", "shortcode": "wu2", "char": "x"},
+			},
+			expected: "example-westus2-we",
+		},
+		"location aliases": {
+			pattern: "{basename}-{loc}-{l}",
+			arguments: map[string]map[string]string{
+				"location": {"fullname": "westus2", "shortcode": "wu2", "char": "x"},
+			},
+			expected: "example-wu2-x",
+		},
+		"default location": {
+			pattern:         "{basename}-{location:short}",
+			defaultLocation: true,
+			expected:        "example-neu",
+		},
+		"function call location overrides default location": {
+			pattern: "{basename}-{location:short}",
+			arguments: map[string]map[string]string{
+				"location": {"fullname": "westus2", "shortcode": "wu2"},
+			},
+			defaultLocation: true,
+			expected:        "example-wu2",
+		},
+		"fallback to region": {
+			pattern:  "{basename}-{location:short}",
+			expected: "example-we",
+		},
+		"fallback to function call region": {
+			pattern: "{basename}-{loc}",
+			arguments: map[string]map[string]string{
+				"region": {"fullname": "eastus", "shortcode": "eus"},
+			},
+			expected: "example-eus",
+		},
+		"explicit fallback to region": {
+			pattern:  "{basename}-{location:char}",
+			fallback: types.BoolValue(true),
+			expected: "example-w",
+		},
+		"fallback disabled": {
+			pattern:  "{basename}-{location}",
+			fallback: types.BoolValue(false),
+			summary:  "Unresolved Components",
+		},
+		"fallback disabled with optional location": {
+			pattern:  "{basename}[-{location}]",
+			fallback: types.BoolValue(false),
+			expected: "example",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{"azurerm_resource_group": testCase.pattern})
+			config.LocationFallbackToRegion = testCase.fallback
+			if testCase.defaultLocation {
+				config.DefaultLocation = testComponentValue(t, "northeurope", "neu", "n")
+			}
+			arguments := map[string]map[string]string{
+				"resource_type": {"fullname": "azurerm_resource_group"},
+			}
+			for key, value := range testCase.arguments {
+				arguments[key] = value
+			}
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+			if testCase.summary != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != testCase.summary {
+					t.Fatalf("expected error %q, got %v", testCase.summary, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_ProviderAdditionalComponents(t *testing.T) {
+	providerComponents := types.MapValueMust(NewComponentValueType(), map[string]attr.Value{
+		"{department}":  testComponentValue(t, "engineering", "eng", "e"),
+		"{cost_owner}":  testComponentValue(t, "finance", "", ""),
+		"{environment}": testComponentValue(t, "sandbox", "sbx", "s"),
+	})
+	testCases := map[string]struct {
+		pattern   string
+		arguments map[string]map[string]string
+		expected  string
+	}{
+		"provider values": {
+			pattern:  "{basename}-{department}-{department:short}-{department:char}",
+			expected: "example-engineering-eng-e",
+		},
+		"derived representations": {
+			pattern:  "{basename}-{cost_owner:short}-{cost_owner:char}",
+			expected: "example-fin-f",
+		},
+		"function call takes precedence": {
+			pattern: "{basename}-{department:short}",
+			arguments: map[string]map[string]string{
+				"additional_components": {"department.fullname": "marketing", "department.shortcode": "mkt"},
+			},
+			expected: "example-mkt",
+		},
+		"function call fullname takes precedence": {
+			pattern: "{basename}-{department:short}-{department:char}",
+			arguments: map[string]map[string]string{
+				"additional_components": {"department.fullname": "sales"},
+			},
+			expected: "example-sales-s",
+		},
+		"function call attribute of another component": {
+			pattern: "{basename}-{department:short}",
+			arguments: map[string]map[string]string{
+				"additional_components": {"team.fullname": "platform"},
+			},
+			expected: "example-eng",
+		},
+		"built-in components take precedence": {
+			pattern:  "{basename}-{environment:short}",
+			expected: "example-prd",
+		},
+		"hash": {
+			pattern:  "{basename}-{hash:6:department}",
+			expected: "example-" + shortHash("\x00department=engineering", 6),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{"azurerm_resource_group": testCase.pattern})
+			config.AdditionalComponents = providerComponents
+			arguments := map[string]map[string]string{
+				"resource_type": {"fullname": "azurerm_resource_group"},
+			}
+			for key, value := range testCase.arguments {
+				arguments[key] = value
+			}
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_RegisteredComponents(t *testing.T) {
+	testCases := map[string]struct {
+		pattern   string
+		arguments map[string]map[string]string
+		expected  string
+	}{
+		"function call": {
+			pattern: "{basename}-{tenant}-{data_classification:short}",
+			arguments: map[string]map[string]string{
+				"tenant":              {"fullname": "contoso", "shortcode": "cto"},
+				"data_classification": {"fullname": "confidential", "shortcode": "conf"},
+			},
+			expected: "example-contoso-conf",
+		},
+		"aliases": {
+			pattern: "{basename}-{ten}-{t}-{dc}",
+			arguments: map[string]map[string]string{
+				"tenant":              {"fullname": "contoso", "shortcode": "cto", "char": "c"},
+				"data_classification": {"fullname": "internal"},
+			},
+			expected: "example-cto-c-i",
+		},
+		"provider defaults": {
+			pattern:  "{basename}-{tenant:short}-{data_classification:char}",
+			expected: "example-fab-p",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{"azurerm_resource_group": testCase.pattern})
+			config.DefaultTenant = testComponentValue(t, "fabrikam", "fab", "f")
+			config.ComponentDefaults = testComponentDefaults(t, map[string]ComponentValueObject{
+				"data_classification": testComponentValue(t, "public", "", ""),
+			})
+			arguments := map[string]map[string]string{
+				"resource_type": {"fullname": "azurerm_resource_group"},
+			}
+			for key, value := range testCase.arguments {
+				arguments[key] = value
+			}
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_Unicode(t *testing.T) {
+	testCases := map[string]struct {
+		resourceType  string
+		pattern       string
+		transliterate bool
+		rules         map[string]namingRule
+		expected      string
+		detail        string
+	}{
+		"shortcode and char are derived per character": {
+			resourceType: "custom_resource",
+			pattern:      "{basename}-{region:short}-{region:char}",
+			expected:     "example-Zür-Z",
+		},
+		"transliterate": {
+			resourceType:  "custom_resource",
+			pattern:       "{basename}-{region|lower}-{region:short}",
+			transliterate: true,
+			expected:      "example-zurich-Zur",
+		},
+		"ascii filter": {
+			resourceType: "custom_resource",
+			pattern:      "{basename}-{region|ascii|lower}",
+			expected:     "example-zurich",
+		},
+		"azure counts utf16 code units": {
+			resourceType: "azurerm_resource_group",
+			pattern:      "{basename}-{region}",
+			rules: map[string]namingRule{
+				"azurerm_resource_group": {MaxLength: intPointer(14), AllowedCharacters: stringPointer(`a-zA-Zü-`)},
+			},
+			expected: "example-Zürich",
+		},
+		"aws counts bytes": {
+			resourceType: "aws_lambda_function",
+			pattern:      "{basename}-{region}",
+			rules: map[string]namingRule{
+				"aws_lambda_function": {MaxLength: intPointer(14), AllowedCharacters: stringPointer(`a-zA-Zü-`)},
+			},
+			detail: "is 15 bytes long, exceeding the max_length of 14",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{testCase.resourceType: testCase.pattern})
+			config.DefaultRegion = testComponentValue(t, "Zürich", "", "")
+			config.Transliterate = types.BoolValue(testCase.transliterate)
+			config.NamingRules = testNamingRules(t, testCase.rules)
+			arguments := map[string]map[string]string{
+				"resource_type": {"fullname": testCase.resourceType},
+			}
+			result, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+			if testCase.detail != "" {
+				if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), testCase.detail) {
+					t.Fatalf("expected error containing %q, got %v", testCase.detail, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+			if !utf8.ValidString(result) {
+				t.Errorf("expected a valid UTF-8 name, got %q", result)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceName_Deterministic(t *testing.T) {
+	pattern := "{resource_type:short}-{basename}-{department:short}-{environment:short}[-{team}]-{region:char}{instance:%03d}-{hash:4:basename,region}"
+	arguments := map[string]map[string]string{
+		"resource_type": {"fullname": "azurerm_resource_group", "shortcode": "rg"},
+		"basename":      {"fullname": "web{region}app"},
+		"region":        {"fullname": "{environment}", "char": "w"},
+		"instance":      {"fullname": "7"},
+		"additional_components": {
+			"department.fullname": "engineering",
+			"team.fullname":       "platform",
+			"owner.fullname":      "{basename}",
+		},
+	}
+	// Component values that look like placeholders are rejected instead of being expanded
+	_, diags := testGenerateResourceName(t, pattern, arguments)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Component Value" {
+		t.Fatalf("expected an Invalid Component Value error, got %v", diags)
+	}
+
+	arguments["basename"] = map[string]string{"fullname": "webapp"}
+	arguments["region"] = map[string]string{"fullname": "westeurope", "char": "w"}
+	expected := "rg-webapp-engineering-prd-platform-w007-" + shortHash("\x00basename=webapp\x00region=westeurope", 4)
+	// The map iteration order of the components differs between runs, the generated name must not
+	for i := 0; i < 50; i++ {
+		result, diags := testGenerateResourceName(t, pattern, arguments)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if result != expected {
+			t.Fatalf("run %d: expected %q, got %q", i, expected, result)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	_ "embed" // Import the embed package
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+//go:embed descriptions/generate_resource_names_description.txt
+var generateResourceNamesDescription string
+
+//go:embed descriptions/generate_resource_names_markdown_description.md
+var generateResourceNamesMarkdownDescription string
+
+// GenerateResourceNamesFunction implements function.Function, it generates the names of many resource types that
+// share the same component parameters in a single call
+type GenerateResourceNamesFunction struct {
+	// Store the provider configuration pointer itself
+	config *resourcenamingtoolProviderModel
+}
+
+// NewGenerateResourceNamesFunction creates a new instance with the provider config
+func NewGenerateResourceNamesFunction(config *resourcenamingtoolProviderModel) function.Function {
+	return &GenerateResourceNamesFunction{
+		config: config,
+	}
+}
+
+func (f *GenerateResourceNamesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "generate_resource_names"
+}
+
+func (f *GenerateResourceNamesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	logDebug(ctx, "Defining generate_resource_names function")
+
+	resp.Definition = function.Definition{
+		Summary:             "Generate the resource names of many resource types that share the same parameters.",
+		Description:         generateResourceNamesDescription,
+		MarkdownDescription: generateResourceNamesMarkdownDescription,
+		Parameters: []function.Parameter{
+			function.SetParameter{
+				Name:        "parameters",
+				Description: "A set of parameters shared by all resource names, the same as for generate_resource_name, without the resource_type.",
+				ElementType: types.MapType{
+					ElemType: types.MapType{
+						ElemType: types.StringType,
+					},
+				},
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.DynamicParameter{
+				Name:        "resource_types",
+				Description: "A list of resource types, or a map of keys to resource types (e.g., { app = \"azurerm_linux_web_app\" }).",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *GenerateResourceNamesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	logDebug(ctx, "Invoking GenerateResourceNamesFunction...")
+
+	var parametersSet types.Set
+	var resourceTypesValue types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &parametersSet, &resourceTypesValue)
+	if resp.Error != nil {
+		return
+	}
+
+	resourceTypes, err := resourceTypesFromDynamic(resourceTypesValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "Invalid resource_types: "+err.Error())
+		return
+	}
+
+	// The parameters are converted and the provider configuration is loaded once for all resource types
+	resourceParams, err := setWithNestedMapsToResourceNamingParametersValue(ctx, parametersSet)
+	if err != nil {
+		logError(ctx, "Failed to convert parameters: %s", err.Error())
+		resp.Error = function.NewFuncError("Failed to convert parameters: " + err.Error())
+		return
+	}
+	config := functionProviderConfig(ctx, f.config)
+
+	names, diags := generateResourceNames(ctx, resourceParams, resourceTypes, config)
+	if funcErr := functionErrorFromDiagnostics(ctx, diags); funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	logDebugWithFields(ctx, "Generated resource names", map[string]interface{}{
+		"names": fmt.Sprintf("%v", names),
+	})
+	resp.Error = resp.Result.Set(ctx, names)
+}
+
+// resourceTypesFromDynamic returns the resource types of the resource_types argument keyed by the name they are
+// returned under: a list, tuple or set of resource types is keyed by the resource types themselves, a map or object
+// by its keys
+func resourceTypesFromDynamic(value types.Dynamic) (map[string]string, error) {
+	if value.IsNull() || value.IsUnknown() || value.IsUnderlyingValueNull() || value.IsUnderlyingValueUnknown() {
+		return nil, fmt.Errorf("a list or map of resource types is required")
+	}
+
+	var keys []string
+	var elements []attr.Value
+	switch underlying := value.UnderlyingValue().(type) {
+	case types.List:
+		elements = underlying.Elements()
+	case types.Tuple:
+		elements = underlying.Elements()
+	case types.Set:
+		elements = underlying.Elements()
+	case types.Map:
+		keys, elements = sortedAttributeValues(underlying.Elements())
+	case types.Object:
+		keys, elements = sortedAttributeValues(underlying.Attributes())
+	default:
+		return nil, fmt.Errorf("expected a list or map of resource types, got %s", underlying.Type(context.Background()))
+	}
+
+	resourceTypes := make(map[string]string, len(elements))
+	for i, element := range elements {
+		resourceType, ok := element.(types.String)
+		if !ok || resourceType.IsNull() || resourceType.IsUnknown() || resourceType.ValueString() == "" {
+			return nil, fmt.Errorf("element %d must be a resource type, got %s", i, element)
+		}
+		key := resourceType.ValueString()
+		if keys != nil {
+			key = keys[i]
+		}
+		resourceTypes[key] = resourceType.ValueString()
+	}
+	return resourceTypes, nil
+}
+
+// sortedAttributeValues returns the keys of the attributes in alphabetical order and the values in the same order
+func sortedAttributeValues(attributes map[string]attr.Value) ([]string, []attr.Value) {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	values := make([]attr.Value, len(keys))
+	for i, key := range keys {
+		values[i] = attributes[key]
+	}
+	return keys, values
+}
+
+// generateResourceNames generates the name of every resource type with the same parameters, the names are keyed the
+// same way as resourceTypes. The resource types replace the resource_type component of the parameters.
+func generateResourceNames(ctx context.Context, params ResourceNamingParametersValue, resourceTypes map[string]string, config resourcenamingtoolProviderModel) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if resourceTypeComp, diagResType := params.GetComponentValue(ctx, "resource_type"); !diagResType.HasError() && !resourceTypeComp.IsNull() {
+		diags.AddError("Invalid Parameter", "The resource_type component cannot be passed in the parameters of generate_resource_names, "+
+			"the resource types are passed in the resource_types argument")
+		return nil, diags
+	}
+
+	keys := make([]string, 0, len(resourceTypes))
+	for key := range resourceTypes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	names := make(map[string]string, len(resourceTypes))
+	for _, key := range keys {
+		resourceType, componentDiags := CreateComponentValueObjectFromParts(ctx, resourceTypes[key], "", "")
+		if componentDiags.HasError() {
+			diags.Append(componentDiags...)
+			return nil, diags
+		}
+		name, nameDiags := generateResourceName(ctx, withComponentParameter(ctx, params, "resource_type", resourceType), config)
+		for _, d := range nameDiags.Errors() {
+			diags.AddError(d.Summary(), fmt.Sprintf("Cannot generate the name for %q: %s", key, d.Detail()))
+		}
+		names[key] = name
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+	return names, diags
+}
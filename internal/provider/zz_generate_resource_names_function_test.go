@@ -0,0 +1,166 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestGenerateResourceNamesFunction_Basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+output "test" {
+  value = provider::resourcenamingtool::generate_resource_names([], { main = "azurerm_resource_group" })["main"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("test", "rg-example-prd-we"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceTypesFromDynamic(t *testing.T) {
+	resourceTypes := []attr.Value{types.StringValue("azurerm_resource_group"), types.StringValue("azurerm_key_vault")}
+	testCases := map[string]struct {
+		value    types.Dynamic
+		expected map[string]string
+		message  string
+	}{
+		"list": {
+			value:    types.DynamicValue(types.ListValueMust(types.StringType, resourceTypes)),
+			expected: map[string]string{"azurerm_resource_group": "azurerm_resource_group", "azurerm_key_vault": "azurerm_key_vault"},
+		},
+		"tuple": {
+			value:    types.DynamicValue(types.TupleValueMust([]attr.Type{types.StringType, types.StringType}, resourceTypes)),
+			expected: map[string]string{"azurerm_resource_group": "azurerm_resource_group", "azurerm_key_vault": "azurerm_key_vault"},
+		},
+		"map": {
+			value: types.DynamicValue(types.MapValueMust(types.StringType, map[string]attr.Value{
+				"main":    types.StringValue("azurerm_resource_group"),
+				"secrets": types.StringValue("azurerm_key_vault"),
+			})),
+			expected: map[string]string{"main": "azurerm_resource_group", "secrets": "azurerm_key_vault"},
+		},
+		"object": {
+			value: types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{"main": types.StringType}, map[string]attr.Value{
+				"main": types.StringValue("azurerm_resource_group"),
+			})),
+			expected: map[string]string{"main": "azurerm_resource_group"},
+		},
+		"null": {
+			value:   types.DynamicNull(),
+			message: "a list or map of resource types is required",
+		},
+		"string": {
+			value:   types.DynamicValue(types.StringValue("azurerm_resource_group")),
+			message: "expected a list or map of resource types, got basetypes.StringType",
+		},
+		"empty resource type": {
+			value:   types.DynamicValue(types.ListValueMust(types.StringType, []attr.Value{types.StringValue("")})),
+			message: "element 0 must be a resource type",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resourceTypes, err := resourceTypesFromDynamic(testCase.value)
+			if testCase.message != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.message) {
+					t.Fatalf("expected error containing %q, got %v", testCase.message, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(resourceTypes, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, resourceTypes)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceNames(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{
+		"azurerm_resource_group":  "rg-{basename}-{environment:short}",
+		"azurerm_key_vault":       "kv-{basename}-{environment:short}",
+		"azurerm_storage_account": "st{basename}{environment:short}",
+	})
+	arguments := map[string]map[string]string{
+		"basename":    {"fullname": "payroll"},
+		"environment": {"fullname": "development", "shortcode": "dev"},
+	}
+	resourceTypes := map[string]string{
+		"main":    "azurerm_resource_group",
+		"secrets": "azurerm_key_vault",
+		"data":    "azurerm_storage_account",
+		"logs":    "azurerm_storage_account",
+	}
+
+	names, diags := generateResourceNames(context.Background(), testNamingParameters(t, arguments), resourceTypes, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := map[string]string{
+		"main":    "rg-payroll-dev",
+		"secrets": "kv-payroll-dev",
+		"data":    "stpayrolldev",
+		"logs":    "stpayrolldev",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestGenerateResourceNames_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		arguments     map[string]map[string]string
+		resourceTypes map[string]string
+		summary       string
+		detail        string
+	}{
+		"resource type parameter": {
+			arguments:     map[string]map[string]string{"resource_type": {"fullname": "azurerm_key_vault"}},
+			resourceTypes: map[string]string{"main": "azurerm_resource_group"},
+			summary:       "Invalid Parameter",
+			detail:        "The resource_type component cannot be passed in the parameters of generate_resource_names",
+		},
+		"missing pattern": {
+			resourceTypes: map[string]string{"main": "azurerm_resource_group", "bucket": "aws_s3_bucket"},
+			summary:       "Missing Pattern",
+			detail:        "Cannot generate the name for \"bucket\": No naming pattern found for resource type: aws_s3_bucket",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{"azurerm_resource_group": "rg-{basename}"})
+			names, diags := generateResourceNames(context.Background(), testNamingParameters(t, testCase.arguments), testCase.resourceTypes, config)
+			if !diags.HasError() {
+				t.Fatalf("expected an error, got %v", names)
+			}
+			if diags.Errors()[0].Summary() != testCase.summary {
+				t.Errorf("expected summary %q, got %q", testCase.summary, diags.Errors()[0].Summary())
+			}
+			if !strings.Contains(diags.Errors()[0].Detail(), testCase.detail) {
+				t.Errorf("expected detail containing %q, got %q", testCase.detail, diags.Errors()[0].Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	_ "embed" // Import the embed package
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+//go:embed descriptions/parse_resource_name_description.txt
+var parseResourceNameDescription string
+
+//go:embed descriptions/parse_resource_name_markdown_description.md
+var parseResourceNameMarkdownDescription string
+
+// ParseResourceNameFunction implements function.Function, it decomposes a name into the values of its components
+type ParseResourceNameFunction struct {
+	// Store the provider configuration pointer itself
+	config *resourcenamingtoolProviderModel
+}
+
+// NewParseResourceNameFunction creates a new instance with the provider config
+func NewParseResourceNameFunction(config *resourcenamingtoolProviderModel) function.Function {
+	return &ParseResourceNameFunction{
+		config: config,
+	}
+}
+
+func (f *ParseResourceNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_resource_name"
+}
+
+func (f *ParseResourceNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	logDebug(ctx, "Defining parse_resource_name function")
+
+	resp.Definition = function.Definition{
+		Summary:             "Decompose a resource name into the values of the components of its naming pattern.",
+		Description:         parseResourceNameDescription,
+		MarkdownDescription: parseResourceNameMarkdownDescription,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "The resource name to decompose.",
+			},
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The resource type whose naming pattern the name is matched against (e.g., 'azurerm_resource_group').",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.MapType{
+				ElemType: types.StringType,
+			},
+		},
+	}
+}
+
+func (f *ParseResourceNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	logDebug(ctx, "Invoking ParseResourceNameFunction...")
+
+	var name, resourceType string
+	resp.Error = req.Arguments.Get(ctx, &name, &resourceType)
+	if resp.Error != nil {
+		return
+	}
+
+	config := functionProviderConfig(ctx, f.config)
+	components, diags := parseResourceName(ctx, name, resourceType, config)
+	if funcErr := functionErrorFromDiagnostics(ctx, diags); funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	logDebugWithFields(ctx, "Parsed resource name", map[string]interface{}{
+		"name":       name,
+		"components": fmt.Sprintf("%v", components),
+	})
+	resp.Error = resp.Result.Set(ctx, components)
+}
+
+// parseResourceName matches name against the naming pattern of the resource type and returns the values of its
+// components, keyed by fullname, shortcode and char. The provider defaults and additional components are used to
+// recover the representations of a component that are not part of the name.
+func parseResourceName(ctx context.Context, name, resourceType string, config resourcenamingtoolProviderModel) (map[string]map[string]string, diag.Diagnostics) {
+	// Decode the configured abbreviations once, they are looked up for every known component value
+	if diags := decodeAbbreviations(ctx, &config); diags.HasError() {
+		return nil, diags
+	}
+	pattern, parsedPattern, diags := lookupConfiguredNamingPattern(config, resourceType)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	constraint := lookupNamingConstraint(ctx, config, resourceType)
+	sanitizeFilters, err := constraint.sanitizeFilters()
+	if err != nil {
+		diags.AddError("Invalid Naming Rule", fmt.Sprintf("Naming rules for resource type %s are invalid: %s", resourceType, err.Error()))
+		return nil, diags
+	}
+
+	components, err := parsedPattern.match(name, patternMatcher{
+		Separator:  lookupSeparator(ctx, config, constraint, nil),
+		Characters: constraint.AllowedCharacters,
+		// Known values are transformed the same way as in generateResourceName
+		Render: func(node patternNode, value string) string {
+			if isComponentPlaceholder(node) && config.Transliterate.ValueBool() {
+				value = transliterateASCII(value)
+			}
+			return applyPatternFilters(applyPatternFilters(value, node.Filters), sanitizeFilters)
+		},
+		Known: knownComponentValues(ctx, config),
+	})
+	if err != nil {
+		logErrorWithFields(ctx, "Failed to parse resource name", map[string]interface{}{
+			"name":          name,
+			"resource_type": resourceType,
+			"pattern":       pattern,
+			"error":         err.Error(),
+		})
+		var mismatch *nameMismatchError
+		if errors.As(err, &mismatch) {
+			diags.AddError("Name Does Not Match Pattern", fmt.Sprintf("Resource name %q %s for resource type %s", mismatch.Name, mismatch.Reason, resourceType))
+		} else {
+			diags.AddError("Invalid Naming Pattern", fmt.Sprintf("Cannot match names of resource type %s against the naming pattern %q: %s", resourceType, pattern, err.Error()))
+		}
+		return nil, diags
+	}
+	return components, diags
+}
+
+// knownComponentValues returns the values of the components known from the provider configuration: the defaults of
+// the built-in components and the additional components. A missing shortcode or char is taken from the abbreviation
+// catalogs, knownRepresentation derives the remaining ones.
+func knownComponentValues(ctx context.Context, config resourcenamingtoolProviderModel) map[string][]map[string]string {
+	known := make(map[string][]map[string]string)
+	for _, definition := range componentRegistry {
+		defaults := definition.Default(config)
+		if defaults.IsNull() || defaults.IsUnknown() {
+			continue
+		}
+		fullname, _ := defaults.GetFullname(ctx)
+		shortcode, _ := defaults.GetShortcode(ctx)
+		char, _ := defaults.GetChar(ctx)
+		// Name generation abbreviates a fullname from the abbreviation catalogs before it falls back to its prefix
+		if shortcode == "" {
+			shortcode, _ = abbreviateFullname(ctx, config, definition.Name, "short", fullname)
+		}
+		if char == "" {
+			char, _ = abbreviateFullname(ctx, config, definition.Name, "char", fullname)
+		}
+		known[definition.Name] = append(known[definition.Name], map[string]string{
+			"fullname":  fullname,
+			"shortcode": shortcode,
+			"char":      char,
+		})
+	}
+	for name, values := range getProviderAdditionalComponentGroups(ctx, config) {
+		known[name] = append(known[name], values)
+	}
+	return known
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestParseResourceNameFunction_Basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+output "test" {
+  value = provider::resourcenamingtool::parse_resource_name(provider::resourcenamingtool::generate_resource_name([]), "azurerm_resource_group")["environment"]["shortcode"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("test", "prd"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseResourceName(t *testing.T) {
+	testCases := map[string]struct {
+		patterns     map[string]string
+		resourceType string
+		name         string
+		expected     map[string]map[string]string
+	}{
+		"provider defaults": {
+			patterns:     map[string]string{"azurerm_resource_group": "rg-{basename}-{environment:short}-{region:short}-{instance:short}"},
+			resourceType: "azurerm_resource_group",
+			name:         "rg-payroll-prd-we-001",
+			expected: map[string]map[string]string{
+				"basename":    {"fullname": "payroll"},
+				"environment": {"fullname": "production", "shortcode": "prd", "char": "p"},
+				"region":      {"fullname": "westeurope", "shortcode": "we", "char": "w"},
+				"instance":    {"fullname": "00001", "shortcode": "001", "char": "1"},
+			},
+		},
+		"provider additional components": {
+			patterns:     map[string]string{"azurerm_resource_group": "rg-{basename}-{department:short}-{e}"},
+			resourceType: "azurerm_resource_group",
+			name:         "rg-payroll-fin-p",
+			expected: map[string]map[string]string{
+				"basename":    {"fullname": "payroll"},
+				"department":  {"fullname": "finance", "shortcode": "fin", "char": "f"},
+				"environment": {"fullname": "production", "shortcode": "prd", "char": "p"},
+			},
+		},
+		"sanitized values": {
+			patterns:     map[string]string{"azurerm_storage_account": "st{basename}{environment:short}{region:short}"},
+			resourceType: "azurerm_storage_account",
+			name:         "stpayrollprdwe",
+			expected: map[string]map[string]string{
+				"basename":    {"fullname": "payroll"},
+				"environment": {"fullname": "production", "shortcode": "prd", "char": "p"},
+				"region":      {"fullname": "westeurope", "shortcode": "we", "char": "w"},
+			},
+		},
+		"wildcard pattern and fragments": {
+			patterns:     map[string]string{"azurerm_*": "{resource_type:short}-{basename}-{@suffix}"},
+			resourceType: "azurerm_key_vault",
+			name:         "kv-payroll-prd-we",
+			expected: map[string]map[string]string{
+				"resource_type": {"shortcode": "kv"},
+				"basename":      {"fullname": "payroll"},
+				"environment":   {"fullname": "production", "shortcode": "prd", "char": "p"},
+				"region":        {"fullname": "westeurope", "shortcode": "we", "char": "w"},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, testCase.patterns)
+			config.DefaultResourceType = ComponentValueObject{}
+			config.DefaultBasename = ComponentValueObject{}
+			config.PatternFragments = types.MapValueMust(types.StringType, map[string]attr.Value{
+				"suffix": types.StringValue("{environment:short}-{region:short}"),
+			})
+			config.AdditionalComponents = types.MapValueMust(NewComponentValueType(), map[string]attr.Value{
+				"{department}": testComponentValue(t, "finance", "fin", ""),
+			})
+			components, diags := parseResourceName(context.Background(), testCase.name, testCase.resourceType, config)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !reflect.DeepEqual(components, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, components)
+			}
+		})
+	}
+}
+
+func TestParseResourceName_RoundTrip(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{
+		"azurerm_resource_group": "rg-{basename}-{environment:short}-{region:short}[-{instance:char}]",
+	})
+	arguments := map[string]map[string]string{
+		"resource_type": {"fullname": "azurerm_resource_group"},
+	}
+	name, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	components, diags := parseResourceName(context.Background(), name, "azurerm_resource_group", config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := map[string]map[string]string{
+		"basename":    {"fullname": "example", "shortcode": "ex", "char": "e"},
+		"environment": {"fullname": "production", "shortcode": "prd", "char": "p"},
+		"region":      {"fullname": "westeurope", "shortcode": "we", "char": "w"},
+		"instance":    {"fullname": "00001", "shortcode": "001", "char": "1"},
+	}
+	if !reflect.DeepEqual(components, expected) {
+		t.Errorf("expected %v, got %v", expected, components)
+	}
+}
+
+func TestParseResourceName_RoundTripAbbreviation(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{
+		"azurerm_resource_group": "{resource_type:short}-{basename}-{region:short}",
+	})
+	config.DefaultRegion = testComponentValue(t, "germanywestcentral", "", "")
+	arguments := map[string]map[string]string{
+		"resource_type": {"fullname": "azurerm_resource_group"},
+	}
+	name, diags := generateResourceName(context.Background(), testNamingParameters(t, arguments), config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if name != "rg-example-gwc" {
+		t.Fatalf("expected name %q, got %q", "rg-example-gwc", name)
+	}
+
+	components, diags := parseResourceName(context.Background(), name, "azurerm_resource_group", config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := map[string]string{"fullname": "germanywestcentral", "shortcode": "gwc", "char": "g"}
+	if !reflect.DeepEqual(components["region"], expected) {
+		t.Errorf("expected region %v, got %v", expected, components["region"])
+	}
+}
+
+func TestParseResourceName_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		resourceType string
+		name         string
+		summary      string
+		detail       string
+	}{
+		"missing pattern": {
+			resourceType: "aws_s3_bucket",
+			name:         "payroll",
+			summary:      "Missing Pattern",
+			detail:       "No naming pattern found for resource type: aws_s3_bucket",
+		},
+		"name doesn't match": {
+			resourceType: "azurerm_resource_group",
+			name:         "st-payroll",
+			summary:      "Name Does Not Match Pattern",
+			detail:       "Resource name \"st-payroll\" doesn't match the naming pattern \"rg-{basename}-{environment:short}\" for resource type azurerm_resource_group",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{"azurerm_resource_group": "rg-{basename}-{environment:short}"})
+			_, diags := parseResourceName(context.Background(), testCase.name, testCase.resourceType, config)
+			if !diags.HasError() {
+				t.Fatalf("expected an error")
+			}
+			if diags.Errors()[0].Summary() != testCase.summary {
+				t.Errorf("expected summary %q, got %q", testCase.summary, diags.Errors()[0].Summary())
+			}
+			if !strings.Contains(diags.Errors()[0].Detail(), testCase.detail) {
+				t.Errorf("expected detail containing %q, got %q", testCase.detail, diags.Errors()[0].Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	_ "embed" // Import the embed package
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+//go:embed descriptions/validate_resource_name_description.txt
+var validateResourceNameDescription string
+
+//go:embed descriptions/validate_resource_name_markdown_description.md
+var validateResourceNameMarkdownDescription string
+
+// ValidateResourceNameFunction implements function.Function, it checks an existing name against the naming
+// convention of a resource type
+type ValidateResourceNameFunction struct {
+	// Store the provider configuration pointer itself
+	config *resourcenamingtoolProviderModel
+}
+
+// NewValidateResourceNameFunction creates a new instance with the provider config
+func NewValidateResourceNameFunction(config *resourcenamingtoolProviderModel) function.Function {
+	return &ValidateResourceNameFunction{
+		config: config,
+	}
+}
+
+// resourceNameValidation is the result of the validate_resource_name function
+type resourceNameValidation struct {
+	// Valid is true when the name matches the naming pattern and complies with the naming rules
+	Valid bool `tfsdk:"valid"`
+	// Violations holds a message for every violation, in the order they were found
+	Violations []string `tfsdk:"violations"`
+	// Pattern is the naming pattern the name was checked against, empty when the resource type has no pattern
+	Pattern string `tfsdk:"pattern"`
+}
+
+// resourceNameValidationAttributeTypes are the attribute types of the object returned by validate_resource_name
+var resourceNameValidationAttributeTypes = map[string]attr.Type{
+	"valid":      types.BoolType,
+	"violations": types.ListType{ElemType: types.StringType},
+	"pattern":    types.StringType,
+}
+
+func (f *ValidateResourceNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_resource_name"
+}
+
+func (f *ValidateResourceNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	logDebug(ctx, "Defining validate_resource_name function")
+
+	resp.Definition = function.Definition{
+		Summary:             "Check a resource name against the naming pattern and naming rules of a resource type.",
+		Description:         validateResourceNameDescription,
+		MarkdownDescription: validateResourceNameMarkdownDescription,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "The resource name to check.",
+			},
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The resource type whose naming pattern and naming rules the name is checked against (e.g., 'azurerm_storage_account').",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: resourceNameValidationAttributeTypes,
+		},
+	}
+}
+
+func (f *ValidateResourceNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	logDebug(ctx, "Invoking ValidateResourceNameFunction...")
+
+	var name, resourceType string
+	resp.Error = req.Arguments.Get(ctx, &name, &resourceType)
+	if resp.Error != nil {
+		return
+	}
+
+	config := functionProviderConfig(ctx, f.config)
+	result, diags := validateResourceName(ctx, name, resourceType, config)
+	if funcErr := functionErrorFromDiagnostics(ctx, diags); funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	logDebugWithFields(ctx, "Validated resource name", map[string]interface{}{
+		"name":       name,
+		"valid":      result.Valid,
+		"violations": fmt.Sprintf("%v", result.Violations),
+	})
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// validateResourceName checks name against the naming pattern and the naming rules of the resource type. A name that
+// violates the naming convention is not an error, the violations are returned in the result. Errors are only
+// returned for an invalid provider configuration, such as a naming pattern with a syntax error.
+func validateResourceName(ctx context.Context, name, resourceType string, config resourcenamingtoolProviderModel) (resourceNameValidation, diag.Diagnostics) {
+	result := resourceNameValidation{Violations: []string{}}
+
+	components, diags := parseResourceName(ctx, name, resourceType, config)
+	for _, d := range diags.Errors() {
+		switch d.Summary() {
+		case "Missing Pattern", "Name Does Not Match Pattern":
+			result.Violations = append(result.Violations, d.Detail())
+		default:
+			return result, diags
+		}
+	}
+	if pattern, _, patternDiags := lookupConfiguredNamingPattern(config, resourceType); !patternDiags.HasError() {
+		result.Pattern = pattern
+	}
+
+	constraint := lookupNamingConstraint(ctx, config, resourceType)
+	for _, violation := range constraint.check(name, resourceType) {
+		result.Violations = append(result.Violations, violation.Detail)
+	}
+
+	result.Valid = len(result.Violations) == 0
+	logDebugWithFields(ctx, "Checked resource name against the naming convention", map[string]interface{}{
+		"name":          name,
+		"resource_type": resourceType,
+		"pattern":       result.Pattern,
+		"components":    fmt.Sprintf("%v", components),
+		"valid":         result.Valid,
+	})
+	return result, nil
+}
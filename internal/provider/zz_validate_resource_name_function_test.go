@@ -0,0 +1,104 @@
+// Copyright (c) Thomas Geens
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestValidateResourceNameFunction_Basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+output "valid" {
+  value = provider::resourcenamingtool::validate_resource_name("rg-example-prd-we", "azurerm_resource_group").valid
+}
+
+output "invalid" {
+  value = provider::resourcenamingtool::validate_resource_name("rg example", "azurerm_resource_group").valid
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("valid", "true"),
+					resource.TestCheckOutput("invalid", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateResourceName(t *testing.T) {
+	testCases := map[string]struct {
+		resourceType string
+		name         string
+		pattern      string
+		violations   []string
+	}{
+		"valid": {
+			resourceType: "azurerm_storage_account",
+			name:         "stpayrollprdwe",
+			pattern:      "st{basename}{environment:short}{region:short}",
+		},
+		"pattern and naming rules": {
+			resourceType: "azurerm_storage_account",
+			name:         "st-payroll",
+			pattern:      "st{basename}{environment:short}{region:short}",
+			violations: []string{
+				"Resource name \"st-payroll\" doesn't match the naming pattern \"st{basename}{environment:short}{region:short}\" for resource type azurerm_storage_account",
+				"Resource name \"st-payroll\" contains \"-\", which is not allowed by the allowed_characters rule [a-z0-9] for resource type azurerm_storage_account",
+			},
+		},
+		"naming rules only": {
+			resourceType: "azurerm_storage_account",
+			name:         "stpayrollandmanymoreprdwe",
+			pattern:      "st{basename}{environment:short}{region:short}",
+			violations: []string{
+				"Resource name \"stpayrollandmanymoreprdwe\" is 25 characters long, exceeding the max_length of 24 for resource type azurerm_storage_account",
+			},
+		},
+		"missing pattern": {
+			resourceType: "aws_s3_bucket",
+			name:         "payroll-prd",
+			violations: []string{
+				"No naming pattern found for resource type: aws_s3_bucket",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := testNamingConfig(t, map[string]string{"azurerm_storage_account": "st{basename}{environment:short}{region:short}"})
+			result, diags := validateResourceName(context.Background(), testCase.name, testCase.resourceType, config)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if result.Valid != (len(testCase.violations) == 0) {
+				t.Errorf("expected valid to be %t, got %t", len(testCase.violations) == 0, result.Valid)
+			}
+			if result.Pattern != testCase.pattern {
+				t.Errorf("expected pattern %q, got %q", testCase.pattern, result.Pattern)
+			}
+			if result.Violations == nil || strings.Join(result.Violations, "\n") != strings.Join(testCase.violations, "\n") {
+				t.Errorf("expected violations %q, got %q", testCase.violations, result.Violations)
+			}
+		})
+	}
+}
+
+func TestValidateResourceName_InvalidPattern(t *testing.T) {
+	config := testNamingConfig(t, map[string]string{"azurerm_resource_group": "rg-{basename"})
+	_, diags := validateResourceName(context.Background(), "rg-payroll", "azurerm_resource_group", config)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Naming Pattern" {
+		t.Errorf("expected an Invalid Naming Pattern error, got %v", diags)
+	}
+}